@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+const aotHelp = `usage: tcvm aot COMMAND, on the AOT root of TCVM_AOTS_ROOT
+  list                 show the blacklisted artifacts
+  clear [NAME]         clear the artifact NAME, or all, from the blacklist
+  recompile NAME       remove the library of the artifact NAME and clear it
+`
+
+// runAot manages the AOT artifacts of a node which is not running, changes
+// are picked up when it starts. It works on the files of the root directory
+// only, without starting an AotService, which needs the compiler.
+func runAot(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(out, aotHelp)
+		return nil
+	}
+
+	cfg, _ := vm.AotConfigFromEnv()
+	list, err := vm.ReadAotBlacklist(cfg.Root)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		fmt.Fprintf(out, "%d blacklisted, root=%s\n", len(list), cfg.Root)
+		for _, e := range list {
+			next := "on clear or compiler change"
+			if !e.NextRetry.IsZero() {
+				next = e.NextRetry.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%s\n  reason: %s\n  compiler: %s\n  failed: %s, %d times\n  retry: %s\n",
+				e.Artifact, e.Reason, e.Compiler, e.Time.Format(time.RFC3339), e.Retries, next)
+		}
+	case "clear":
+		name := ""
+		if len(args) > 1 {
+			name = args[1]
+		}
+		kept, n := clearBlack(list, name)
+		if n != 0 {
+			if err := vm.WriteAotBlacklist(cfg.Root, kept); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(out, "%d cleared\n", n)
+	case "recompile":
+		if len(args) < 2 {
+			return fmt.Errorf("recompile: no artifact")
+		}
+		name := args[1]
+		err := os.Remove(filepath.Join(cfg.Root, name+".so"))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("recompile %s: %v", name, err)
+		}
+		kept, n := clearBlack(list, name)
+		if err != nil && n == 0 {
+			return fmt.Errorf("recompile %s: %v", name, vm.ErrNoNative)
+		}
+		if n != 0 {
+			if err := vm.WriteAotBlacklist(cfg.Root, kept); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(out, "%s is recompiled when next run\n", name)
+	default:
+		fmt.Fprint(out, aotHelp)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return nil
+}
+
+// clearBlack returns the entries of list other than the artifact name, or
+// none if name is empty, and the number of entries dropped.
+func clearBlack(list []vm.AotBlackEntry, name string) ([]vm.AotBlackEntry, int) {
+	kept := make([]vm.AotBlackEntry, 0, len(list))
+	for _, e := range list {
+		if name == "" || e.Artifact == name {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, len(list) - len(kept)
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+const debugHelp = `commands:
+  s, step [n]          execute n instructions (default 1)
+  c, continue          run until the next breakpoint
+  b, break FUNC        break on entry of a wasm function (name or index)
+  hb, hbreak NAME      break before calls to a host function, e.g. TC_StorageSet
+  d, delete NAME       delete a breakpoint
+  bl                   list breakpoints
+  w, where             show the current location
+  l, locals            show locals of the current wasm function
+  st, stack            show the operand stack
+  g, globals           show wasm globals
+  x ADDR [LEN]         hexdump LEN bytes of linear memory (default 64)
+  str ADDR             show the C string at ADDR
+  bt, frames           show the contract call frames (Engine.AppFrames)
+  q, quit              abort tcvm
+`
+
+// cliDebugger drives vm.Debugger from a line based console.
+type cliDebugger struct {
+	in    *bufio.Scanner
+	out   io.Writer
+	steps int
+	dbg   *vm.Debugger
+}
+
+func newCliDebugger(in io.Reader, out io.Writer) *vm.Debugger {
+	c := &cliDebugger{
+		in:  bufio.NewScanner(in),
+		out: out,
+	}
+	c.dbg = vm.NewDebugger(c.handle)
+	fmt.Fprintf(out, "tcvm debugger, type \"help\" for commands\n")
+	return c.dbg
+}
+
+func (c *cliDebugger) handle(ev *vm.DebugEvent) vm.DebugAction {
+	if c.steps > 1 && ev.Kind == vm.DebugStep {
+		c.steps--
+		return vm.DebugStepInto
+	}
+	c.steps = 0
+
+	fmt.Fprintf(c.out, "stopped: %s\n", ev)
+	for {
+		fmt.Fprintf(c.out, "(tcvm) ")
+		if !c.in.Scan() {
+			return vm.DebugContinue
+		}
+		fields := strings.Fields(c.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		cmd, args := fields[0], fields[1:]
+		switch cmd {
+		case "s", "step":
+			c.steps = 1
+			if len(args) > 0 {
+				if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+					c.steps = n
+				}
+			}
+			return vm.DebugStepInto
+		case "c", "continue":
+			return vm.DebugContinue
+		case "b", "break", "hb", "hbreak":
+			if len(args) == 0 {
+				fmt.Fprintf(c.out, "usage: %s NAME\n", cmd)
+				continue
+			}
+			if cmd == "b" || cmd == "break" {
+				c.dbg.BreakFunc(args[0])
+			} else {
+				c.dbg.BreakHost(args[0])
+			}
+		case "d", "delete":
+			if len(args) == 0 || !c.dbg.Clear(args[0]) {
+				fmt.Fprintf(c.out, "no such breakpoint\n")
+			}
+		case "bl":
+			for _, b := range c.dbg.Breakpoints() {
+				fmt.Fprintf(c.out, "  %s\n", b)
+			}
+		case "w", "where":
+			fmt.Fprintf(c.out, "%s\n", ev)
+		case "l", "locals":
+			if ev.Frame != nil {
+				printValues(c.out, "local", ev.Frame.Locals)
+			}
+		case "st", "stack":
+			if ev.Frame != nil {
+				printValues(c.out, "stack", ev.Frame.Stack)
+			}
+		case "g", "globals":
+			printValues(c.out, "global", ev.App.Globals())
+		case "x":
+			c.hexdump(ev.App, args)
+		case "str":
+			c.cstring(ev.App, args)
+		case "bt", "frames":
+			for i, app := range ev.Eng.CallStack() {
+				fmt.Fprintf(c.out, "  #%d %s\n", i, app.String())
+			}
+		case "q", "quit":
+			os.Exit(1)
+		case "h", "help":
+			fmt.Fprint(c.out, debugHelp)
+		default:
+			fmt.Fprintf(c.out, "unknown command %q, type \"help\"\n", cmd)
+		}
+	}
+}
+
+func (c *cliDebugger) hexdump(app *vm.APP, args []string) {
+	hexdump(c.out, app.VM.Memory(), args)
+}
+
+// hexdump implements "x ADDR [LEN]" over a linear memory.
+func hexdump(out io.Writer, mem []byte, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(out, "usage: x ADDR [LEN]\n")
+		return
+	}
+	addr, err := strconv.ParseUint(args[0], 0, 32)
+	if err != nil {
+		fmt.Fprintf(out, "invalid address: %s\n", args[0])
+		return
+	}
+	n := uint64(64)
+	if len(args) > 1 {
+		if n, err = strconv.ParseUint(args[1], 0, 32); err != nil {
+			fmt.Fprintf(out, "invalid length: %s\n", args[1])
+			return
+		}
+	}
+
+	if addr >= uint64(len(mem)) {
+		fmt.Fprintf(out, "address out of memory (size %d)\n", len(mem))
+		return
+	}
+	if addr+n > uint64(len(mem)) {
+		n = uint64(len(mem)) - addr
+	}
+	for off := addr; off < addr+n; off += 16 {
+		end := off + 16
+		if end > addr+n {
+			end = addr + n
+		}
+		line := mem[off:end]
+		ascii := make([]byte, len(line))
+		for i, b := range line {
+			ascii[i] = '.'
+			if b >= 0x20 && b < 0x7f {
+				ascii[i] = b
+			}
+		}
+		fmt.Fprintf(out, "%08x  %-47s  |%s|\n", off, fmt.Sprintf("% x", line), ascii)
+	}
+}
+
+func (c *cliDebugger) cstring(app *vm.APP, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(c.out, "usage: str ADDR\n")
+		return
+	}
+	addr, err := strconv.ParseUint(args[0], 0, 32)
+	if err != nil {
+		fmt.Fprintf(c.out, "invalid address: %s\n", args[0])
+		return
+	}
+	s, err := app.VM.VMemory().GetString(addr)
+	if err != nil {
+		fmt.Fprintf(c.out, "read string fail: %v\n", err)
+		return
+	}
+	fmt.Fprintf(c.out, "%q\n", string(s))
+}
+
+func printValues(w io.Writer, name string, vals []uint64) {
+	if len(vals) == 0 {
+		fmt.Fprintf(w, "  no %ss\n", name)
+		return
+	}
+	for i, v := range vals {
+		fmt.Fprintf(w, "  %s[%d] = %d (0x%x)\n", name, i, int64(v), v)
+	}
+}
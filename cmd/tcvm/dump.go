@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+const dumpHelp = `commands:
+  i, info              show where the contract failed
+  x ADDR [LEN]         hexdump LEN bytes of linear memory (default 64)
+  str ADDR             show the C string at ADDR
+  strings [MIN] [TEXT] list printable strings of at least MIN (default 4) bytes containing TEXT
+  g, globals           show wasm globals
+  l, locals            show locals of the failing wasm function
+  st, stack            show the operand stack
+  json                 show the JSON objects and arrays of the engine
+  q, quit              leave
+`
+
+// inspectDump runs a console over a post-mortem dump written with -dump.
+func inspectDump(d *vm.Dump, in io.Reader, out io.Writer) {
+	fmt.Fprintf(out, "%s: %s\ntype \"help\" for commands\n", dumpWhere(d), d.Err)
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "(dump) ")
+		if !scanner.Scan() {
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		cmd, args := fields[0], fields[1:]
+		switch cmd {
+		case "i", "info":
+			fmt.Fprintf(out, "%s: %s\n", dumpWhere(d), d.Err)
+			fmt.Fprintf(out, "memory %d bytes, %d globals, %d json objects\n", len(d.Memory), len(d.Globals), len(d.JSON))
+		case "x":
+			hexdump(out, d.Memory, args)
+		case "str":
+			dumpCString(out, d.Memory, args)
+		case "strings":
+			min, text := 4, ""
+			if len(args) > 0 {
+				if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+					min = n
+					args = args[1:]
+				}
+			}
+			if len(args) > 0 {
+				text = strings.Join(args, " ")
+			}
+			for _, s := range d.Strings(min, text) {
+				fmt.Fprintf(out, "  0x%08x %q\n", s.Addr, s.Text)
+			}
+		case "g", "globals":
+			printValues(out, "global", d.Globals)
+		case "l", "locals":
+			if d.Frame != nil {
+				printValues(out, "local", d.Frame.Locals)
+			}
+		case "st", "stack":
+			if d.Frame != nil {
+				printValues(out, "stack", d.Frame.Stack)
+			}
+		case "json":
+			for i, obj := range d.JSON {
+				fmt.Fprintf(out, "  #%d\n", i)
+				for k, v := range obj {
+					fmt.Fprintf(out, "    %q: %s\n", k, v)
+				}
+			}
+			for i, arr := range d.Arrays {
+				fmt.Fprintf(out, "  array #%d\n", i)
+				for j, v := range arr {
+					fmt.Fprintf(out, "    [%d]: %s\n", j, v)
+				}
+			}
+		case "q", "quit":
+			return
+		case "h", "help":
+			fmt.Fprint(out, dumpHelp)
+		default:
+			fmt.Fprintf(out, "unknown command %q, type \"help\"\n", cmd)
+		}
+	}
+}
+
+func dumpWhere(d *vm.Dump) string {
+	if d.Source != "" {
+		return fmt.Sprintf("%s at %s (%s)", d.App, d.Where, d.Source)
+	}
+	return fmt.Sprintf("%s at %s", d.App, d.Where)
+}
+
+func dumpCString(out io.Writer, mem []byte, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(out, "usage: str ADDR\n")
+		return
+	}
+	addr, err := strconv.ParseUint(args[0], 0, 32)
+	if err != nil || addr >= uint64(len(mem)) {
+		fmt.Fprintf(out, "invalid address: %s\n", args[0])
+		return
+	}
+	end := addr
+	for end < uint64(len(mem)) && mem[end] != 0 {
+		end++
+	}
+	fmt.Fprintf(out, "%q\n", string(mem[addr:end]))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"flag"
 	"fmt"
@@ -21,7 +22,7 @@ import (
 )
 
 var (
-	helpParams = "-file path/to/testdata/tcvm.wasm -call path/to/testdata/tcvm.params"
+	helpParams = "[debug] -file path/to/testdata/tcvm.wasm -call path/to/testdata/tcvm.params\n    %s dump path/to/dump\n    %s aot list|clear [NAME]|recompile NAME"
 
 	testAddr1 = types.BytesToAddress(types.Keccak256([]byte("addr-1 for call contract"))[:20])
 	testAddr2 = types.BytesToAddress(types.Keccak256([]byte("addr-2 for contract"))[:20])
@@ -36,6 +37,11 @@ var (
 	callFuncFlag  = flag.String("call", "", "file with called function and data")
 	contractGas   = flag.Uint64("gas", 52100, "contract msg gas")
 	contractValue = flag.Uint64("value", 0, "contract msg value")
+	gasProfile    = flag.Bool("gasprofile", false, "print gas used per contract location")
+	coverProfile  = flag.String("coverprofile", "", "print code coverage and write lcov to file (needs DWARF)")
+	recordFile    = flag.String("record", "", "record host calls with their results to file")
+	replayFile    = flag.String("replay", "", "replay host calls from a recording, without state")
+	dumpFile      = flag.String("dump", "", "write memory, globals and stack of a failed run to file")
 )
 
 type MockChainContext struct {
@@ -50,10 +56,48 @@ type MockAccountRef types.Address
 func (ar MockAccountRef) Address() types.Address { return (types.Address)(ar) }
 
 func main() {
+	// tcvm is its own AOT sandbox helper
+	vm.RunSandboxHelper()
+
+	// "tcvm dump FILE" inspects a post-mortem dump
+	if len(os.Args) > 2 && os.Args[1] == "dump" {
+		d, err := vm.ReadDump(os.Args[2])
+		if err != nil {
+			fmt.Printf("ERR read dump %s failed, err: %v\n", os.Args[2], err)
+			return
+		}
+		inspectDump(d, os.Stdin, os.Stdout)
+		return
+	}
+
+	// "tcvm aot ..." manages the AOT artifacts
+	if len(os.Args) > 1 && os.Args[1] == "aot" {
+		if err := runAot(os.Args[2:], os.Stdout); err != nil {
+			fmt.Printf("ERR aot: %v\n", err)
+		}
+		return
+	}
+
+	// "tcvm debug ..." runs the contract under the interactive debugger
+	debugMode := len(os.Args) > 1 && os.Args[1] == "debug"
+	if debugMode {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
 	flag.Parse()
 
+	if cfg, ok := vm.AotConfigFromEnv(); ok {
+		aots, err := vm.StartAotService(cfg)
+		if err != nil {
+			fmt.Printf("ERR start AotService failed, root=%s, err: %v\n", cfg.Root, err)
+			return
+		}
+		fmt.Printf("INFO AotService enabled, root=%s, compiler=%s\n", cfg.Root, aots.Compiler())
+		vm.SetDefaultAotService(aots)
+		defer aots.Stop()
+	}
+
 	if len(*wasmFileFlag) == 0 {
-		fmt.Printf("Usage:\n    %s %s\n\n", os.Args[0], helpParams)
+		fmt.Printf("Usage:\n    %s "+helpParams+"\n\n", os.Args[0], os.Args[0], os.Args[0])
 		fmt.Printf("Use \"%s -h\" for more information\n", os.Args[0])
 		return
 	}
@@ -90,9 +134,27 @@ func main() {
 	ctx.Origin = types.EmptyAddress
 	ctx.GasPrice = testGasPrice
 
-	st, _ := state.New()
-	st.AddBalance(caller.Address(), testBalance1)
-	st.AddBalance(to.Address(), testBalance2)
+	var replayer *vm.Replayer
+	if len(*replayFile) != 0 {
+		f, err := os.Open(*replayFile)
+		if err != nil {
+			fmt.Printf("ERR open %s failed, err: %v\n", *replayFile, err)
+			return
+		}
+		replayer, err = vm.NewReplayer(f)
+		f.Close()
+		if err != nil {
+			fmt.Printf("ERR read %s failed, err: %v\n", *replayFile, err)
+			return
+		}
+	}
+
+	var st *state.StateDB
+	if replayer == nil {
+		st, _ = state.New()
+		st.AddBalance(caller.Address(), testBalance1)
+		st.AddBalance(to.Address(), testBalance2)
+	}
 
 	// info := vm.ContractInfo{
 	// 	Type: "wasm",
@@ -104,9 +166,53 @@ func main() {
 	// infoData, _ := json.Marshal(&info)
 	// st.SetContractInfo(contract.Address().Bytes(), infoData)
 
-	eng := vm.NewEngine(contract, contract.Gas, st, log.With("mod", "wasm"))
+	var eng *vm.Engine
+	if replayer != nil {
+		eng = vm.NewEngine(contract, contract.Gas, replayer, log.With("mod", "wasm"))
+		eng.SetReplayer(replayer)
+		wasm.Inject(&ctx, nil)
+		defer checkReplay(replayer)
+	} else {
+		eng = vm.NewEngine(contract, contract.Gas, st, log.With("mod", "wasm"))
+		wasm.Inject(&ctx, st)
+	}
 	eng.SetTrace(false)
-	wasm.Inject(&ctx, st)
+	if len(*dumpFile) != 0 {
+		eng.SetDumpOnFailure(true)
+	}
+
+	if len(*recordFile) != 0 {
+		f, err := os.Create(*recordFile)
+		if err != nil {
+			fmt.Printf("ERR create %s failed, err: %v\n", *recordFile, err)
+			return
+		}
+		defer f.Close()
+		recorder := vm.NewRecorder(f)
+		eng.SetRecorder(recorder)
+		defer func() {
+			if err := recorder.Err(); err != nil {
+				fmt.Printf("ERR write %s failed, err: %v\n", *recordFile, err)
+			}
+		}()
+	}
+
+	if debugMode {
+		eng.SetDebugger(newCliDebugger(os.Stdin, os.Stdout))
+	}
+
+	var profile *vm.GasProfile
+	if *gasProfile {
+		profile = vm.NewGasProfile()
+		eng.SetGasProfile(profile)
+	}
+
+	var coverage *vm.Coverage
+	if len(*coverProfile) != 0 {
+		coverage = vm.NewCoverage()
+		eng.SetCoverage(coverage)
+		defer writeCoverage(coverage, *coverProfile)
+	}
 
 	start := time.Now()
 
@@ -133,6 +239,10 @@ func main() {
 	if err != nil {
 		fmt.Printf("ERR init vm/Engine.Run failed, func=%s gasUsed=%d gasLeft=%d, err: %s",
 			vm.APPEntry, eng.GasUsed(), eng.Gas(), err)
+		if trap := eng.Trap(); trap != nil {
+			fmt.Printf("\nERR trap: %s\n", trap)
+		}
+		writeDump(eng, *dumpFile)
 		return
 	}
 
@@ -143,8 +253,14 @@ func main() {
 		return
 	}
 
-	fmt.Printf("Waiting gcc compiler...\n")
-	time.Sleep(time.Second * 4)
+	if vm.DefaultAotService() != nil {
+		fmt.Printf("Waiting gcc compiler...\n")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		if err := vm.WaitNative(ctx, app); err != nil {
+			fmt.Printf("INFO no native code, err: %v\n", err)
+		}
+		cancel()
+	}
 
 	initTime := time.Since(start).Seconds()
 
@@ -153,6 +269,9 @@ func main() {
 
 	if len(*callFuncFlag) == 0 {
 		fmt.Println("INFO init finished. You can provide the called function and data via parameter call")
+		if profile != nil {
+			profile.WriteTo(os.Stdout)
+		}
 		return
 	}
 
@@ -182,6 +301,10 @@ func main() {
 	if err != nil {
 		fmt.Printf("ERR call vm/Engine.Run failed, func=%s gasUsed=%d gasLeft=%d, err: %s",
 			vm.APPEntry, eng.GasUsed(), eng.Gas(), err)
+		if trap := eng.Trap(); trap != nil {
+			fmt.Printf("\nERR trap: %s\n", trap)
+		}
+		writeDump(eng, *dumpFile)
 		return
 	}
 
@@ -197,5 +320,51 @@ func main() {
 	fmt.Printf("INFO call done, gasUsed=%d gasLeft=%d time=[%f], return[%d]: %s\n",
 		eng.GasUsed(), eng.Gas(), callTime, len(rBytes), string(rBytes))
 
+	if profile != nil {
+		profile.WriteTo(os.Stdout)
+	}
+
 	return
 }
+
+func writeDump(eng *vm.Engine, file string) {
+	d := eng.Dump()
+	if d == nil || len(file) == 0 {
+		return
+	}
+	if err := d.WriteFile(file); err != nil {
+		fmt.Printf("ERR write dump %s failed, err: %v\n", file, err)
+		return
+	}
+	fmt.Printf("INFO dump written to %s, inspect it with \"%s dump %s\"\n", file, os.Args[0], file)
+}
+
+func checkReplay(replayer *vm.Replayer) {
+	if d := replayer.Divergence(); d != nil {
+		fmt.Printf("ERR replay diverged: %s\n", d)
+		return
+	}
+	if n := replayer.Remaining(); n > 0 {
+		fmt.Printf("ERR replay finished with %d recorded entries left\n", n)
+		return
+	}
+	fmt.Println("INFO replay matches recording")
+}
+
+func writeCoverage(coverage *vm.Coverage, file string) {
+	coverage.WriteSummary(os.Stdout)
+
+	buf := new(bytes.Buffer)
+	ok, err := coverage.WriteLcov(buf)
+	if err != nil {
+		fmt.Printf("ERR write lcov failed, err: %v\n", err)
+		return
+	}
+	if !ok {
+		fmt.Printf("INFO no DWARF line info in contract, %s not written\n", file)
+		return
+	}
+	if err := ioutil.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		fmt.Printf("ERR write %s failed, err: %v\n", file, err)
+	}
+}
@@ -18,46 +18,46 @@ var (
 func init() {
 	env := vm.NewEnvTable()
 
-	env.RegisterFunc("TC_StorageSet", &TCStorageSet{}) //removed
-	env.RegisterFunc("TC_StorageGet", &TCStorageGet{}) //removed
-
-	env.RegisterFunc("TC_StorageSetString", &TCStorageSet{})
-	env.RegisterFunc("TC_StorageSetBytes", &TCStorageSetBytes{})
-	env.RegisterFunc("TC_StoragePureSetString", &TCStoragePureSetString{})
-	env.RegisterFunc("TC_StoragePureSetBytes", &TCStoragePureSetBytes{})
-	env.RegisterFunc("TC_StorageGetString", &TCStorageGet{})
-	env.RegisterFunc("TC_StorageGetBytes", &TCStorageGet{})
-	env.RegisterFunc("TC_StoragePureGetString", &TCStoragePureGet{})
-	env.RegisterFunc("TC_StoragePureGetBytes", &TCStoragePureGet{})
-
-	env.RegisterFunc("TC_StorageDel", &TCStorageDel{})
-	env.RegisterFunc("TC_ContractStorageGet", &TCContractStorageGet{})
-	env.RegisterFunc("TC_ContractStoragePureGet", &TCContractStoragePureGet{})
-	env.RegisterFunc("TC_Notify", &TCNotify{})
-	env.RegisterFunc("TC_BlockHash", &TCBlockHash{})
-	env.RegisterFunc("TC_GetCoinbase", &TCGetCoinbase{})
-	env.RegisterFunc("TC_GetGasLimit", &TCGetGasLimit{})
-	env.RegisterFunc("TC_GetNumber", &TCGetNumber{})
-	env.RegisterFunc("TC_Now", &TCNow{})
-	env.RegisterFunc("TC_GetTxGasPrice", &TCGetTxGasPrice{})
-	env.RegisterFunc("TC_GetTxOrigin", &TCGetTxOrigin{})
-	env.RegisterFunc("TC_Log0", &TCLog0{})
-	env.RegisterFunc("TC_Log1", &TCLog1{})
-	env.RegisterFunc("TC_Log2", &TCLog2{})
-	env.RegisterFunc("TC_Log3", &TCLog3{})
-	env.RegisterFunc("TC_Log4", &TCLog4{})
-	env.RegisterFunc("TC_SelfDestruct", &TCSelfDestruct{})
-	env.RegisterFunc("TC_GetBalance", &TCGetBalance{})
-	env.RegisterFunc("TC_CheckSign", new(TCCheckSign))
-	env.RegisterFunc("TC_Ecrecover", new(TCEcrecover))
-
-	env.RegisterFunc("TC_Issue", &TCIssue{})
-	env.RegisterFunc("TC_Transfer", &TCTransfer{})
-	env.RegisterFunc("TC_TransferToken", &TCTransferToken{})
-	env.RegisterFunc("TC_TokenBalance", &TCTokenBalance{})
-	env.RegisterFunc("TC_TokenAddress", &TCTokenAddress{})
-	env.RegisterFunc("TC_GetMsgValue", &TCGetMsgValue{})
-	env.RegisterFunc("TC_GetMsgTokenValue", &TCGetMsgTokenValue{})
+	env.RegisterStateFunc("TC_StorageSet", &TCStorageSet{}, "ss:v") //removed
+	env.RegisterStateFunc("TC_StorageGet", &TCStorageGet{}, "s:s")  //removed
+
+	env.RegisterStateFunc("TC_StorageSetString", &TCStorageSet{}, "ss:v")
+	env.RegisterStateFunc("TC_StorageSetBytes", &TCStorageSetBytes{}, "sbi:v")
+	env.RegisterStateFunc("TC_StoragePureSetString", &TCStoragePureSetString{}, "bis:v")
+	env.RegisterStateFunc("TC_StoragePureSetBytes", &TCStoragePureSetBytes{}, "bibi:v")
+	env.RegisterStateFunc("TC_StorageGetString", &TCStorageGet{}, "s:s")
+	env.RegisterStateFunc("TC_StorageGetBytes", &TCStorageGet{}, "s:s")
+	env.RegisterStateFunc("TC_StoragePureGetString", &TCStoragePureGet{}, "bi:s")
+	env.RegisterStateFunc("TC_StoragePureGetBytes", &TCStoragePureGet{}, "bi:s")
+
+	env.RegisterStateFunc("TC_StorageDel", &TCStorageDel{}, "s:v")
+	env.RegisterStateFunc("TC_ContractStorageGet", &TCContractStorageGet{}, "ss:s")
+	env.RegisterStateFunc("TC_ContractStoragePureGet", &TCContractStoragePureGet{}, "sbi:s")
+	env.RegisterStateFunc("TC_Notify", &TCNotify{}, "ss:v")
+	env.RegisterStateFunc("TC_BlockHash", &TCBlockHash{}, "i:s")
+	env.RegisterStateFunc("TC_GetCoinbase", &TCGetCoinbase{}, ":s")
+	env.RegisterStateFunc("TC_GetGasLimit", &TCGetGasLimit{}, ":i")
+	env.RegisterStateFunc("TC_GetNumber", &TCGetNumber{}, ":i")
+	env.RegisterStateFunc("TC_Now", &TCNow{}, ":i")
+	env.RegisterStateFunc("TC_GetTxGasPrice", &TCGetTxGasPrice{}, ":i")
+	env.RegisterStateFunc("TC_GetTxOrigin", &TCGetTxOrigin{}, ":s")
+	env.RegisterStateFunc("TC_Log0", &TCLog0{}, "s:v")
+	env.RegisterStateFunc("TC_Log1", &TCLog1{}, "ss:v")
+	env.RegisterStateFunc("TC_Log2", &TCLog2{}, "sss:v")
+	env.RegisterStateFunc("TC_Log3", &TCLog3{}, "ssss:v")
+	env.RegisterStateFunc("TC_Log4", &TCLog4{}, "sssss:v")
+	env.RegisterStateFunc("TC_SelfDestruct", &TCSelfDestruct{}, "s:i")
+	env.RegisterStateFunc("TC_GetBalance", &TCGetBalance{}, "s:s")
+	env.RegisterPureFunc("TC_CheckSign", new(TCCheckSign))
+	env.RegisterPureFunc("TC_Ecrecover", new(TCEcrecover))
+
+	env.RegisterStateFunc("TC_Issue", &TCIssue{}, "s:v")
+	env.RegisterStateFunc("TC_Transfer", &TCTransfer{}, "ss:v")
+	env.RegisterStateFunc("TC_TransferToken", &TCTransferToken{}, "sss:v")
+	env.RegisterStateFunc("TC_TokenBalance", &TCTokenBalance{}, "ss:s")
+	env.RegisterStateFunc("TC_TokenAddress", &TCTokenAddress{}, ":s")
+	env.RegisterStateFunc("TC_GetMsgValue", &TCGetMsgValue{}, ":s")
+	env.RegisterStateFunc("TC_GetMsgTokenValue", &TCGetMsgTokenValue{}, ":s")
 }
 
 func Inject(context *Context, stateDB types.StateDB) {
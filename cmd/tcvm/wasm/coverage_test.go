@@ -0,0 +1,62 @@
+package wasm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestCoverage(t *testing.T) {
+	wasmFile := "../../../testdata/prints.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{203})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	cState.SetCode(addr, code)
+
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+	Inject(&ctx, cState)
+
+	cov := vm.NewCoverage()
+	for i := 0; i < 2; i++ {
+		contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+		contract.CodeAddr = &addr
+		eng := vm.NewEngine(contract, 100000, cState, log.Test())
+		eng.SetCoverage(cov)
+		app, err := eng.NewApp(addr.String(), nil, false)
+		if err != nil {
+			t.Logf("new app fail: err: %v", err)
+			return
+		}
+		if _, err := eng.Run(app, []byte("a|a")); err != nil {
+			t.Fatalf("run fail: %v", err)
+		}
+	}
+
+	found := false
+	for _, f := range cov.Summary() {
+		if f.Func != vm.APPEntry {
+			continue
+		}
+		found = true
+		if f.Calls != 2 || f.Covered == 0 || f.Covered > f.Blocks {
+			t.Fatalf("unexpected coverage: %+v", f)
+		}
+	}
+	if !found {
+		t.Fatalf("no coverage for %s", vm.APPEntry)
+	}
+	cov.WriteSummary(os.Stdout)
+}
@@ -0,0 +1,67 @@
+package wasm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestDebugger(t *testing.T) {
+	wasmFile := "../../../testdata/prints.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{202})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	cState.SetCode(addr, code)
+
+	contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+	contract.CodeAddr = &addr
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+	eng := vm.NewEngine(contract, 100000, cState, log.Test())
+	Inject(&ctx, cState)
+
+	var steps, hosts int
+	var data string
+	dbg := vm.NewDebugger(func(ev *vm.DebugEvent) vm.DebugAction {
+		t.Logf("stopped: %s", ev)
+		if ev.Kind == vm.DebugHostCall {
+			hosts++
+			s, _ := ev.App.VM.VMemory().GetString(ev.Args[0])
+			data = string(s)
+			return vm.DebugStepInto
+		}
+		steps++
+		if steps < 3 {
+			return vm.DebugStepInto
+		}
+		return vm.DebugContinue
+	})
+	dbg.BreakHost("TC_Prints")
+	eng.SetDebugger(dbg)
+
+	app, err := eng.NewApp(addr.String(), nil, false)
+	if err != nil {
+		t.Logf("new app fail: err: %v", err)
+		return
+	}
+	if _, err := eng.Run(app, []byte("a|a")); err != nil {
+		t.Fatalf("run fail: %v", err)
+	}
+	if steps != 3 || hosts != 1 {
+		t.Fatalf("wanted 3 steps and 1 host call, got %d and %d", steps, hosts)
+	}
+	if data != "0x0000000000000000000000000000000000000001" {
+		t.Fatalf("unexpected TC_Prints arg: %s", data)
+	}
+}
@@ -0,0 +1,70 @@
+package wasm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestDumpOnFailure(t *testing.T) {
+	wasmFile := "../../../testdata/token.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{205})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	cState.SetCode(addr, code)
+
+	contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+	contract.CodeAddr = &addr
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+	Inject(&ctx, cState)
+
+	eng := vm.NewEngine(contract, 200, cState, log.Test())
+	eng.SetDumpOnFailure(true)
+	app, err := eng.NewApp(addr.String(), nil, false)
+	if err != nil {
+		t.Logf("new app fail: err: %v", err)
+		return
+	}
+	if _, err = eng.Run(app, []byte("a|a")); err == nil {
+		t.Fatalf("run with 200 gas should fail")
+	}
+
+	d := eng.Dump()
+	if d == nil || d.Frame == nil || len(d.Memory) == 0 || d.Where != eng.Trap().Where {
+		t.Fatalf("unexpected dump: %+v", d)
+	}
+
+	dir, err := ioutil.TempDir("", "tcvm-dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "dump.json")
+	if err := d.WriteFile(file); err != nil {
+		t.Fatalf("write dump fail: %v", err)
+	}
+	d2, err := vm.ReadDump(file)
+	if err != nil {
+		t.Fatalf("read dump fail: %v", err)
+	}
+	if len(d2.Memory) != len(d.Memory) || d2.Err != d.Err || len(d2.Frame.Stack) != len(d.Frame.Stack) {
+		t.Fatalf("dump changed after write/read")
+	}
+	if len(d2.Strings(4, "")) == 0 {
+		t.Fatalf("no strings in dumped memory")
+	}
+}
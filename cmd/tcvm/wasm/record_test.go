@@ -0,0 +1,85 @@
+package wasm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestRecordReplay(t *testing.T) {
+	wasmFile := "../../../testdata/getbalance.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{204})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	balanceAddr := types.HexToAddress("0x0000000000000000000000000000000000000001")
+	cState.AddBalance(balanceAddr, big.NewInt(777))
+	cState.SetCode(addr, code)
+
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+
+	run := func(db vm.StateDB, rec *vm.Recorder, rep *vm.Replayer, input string) (string, uint64, error) {
+		contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+		contract.CodeAddr = &addr
+		eng := vm.NewEngine(contract, 100000, db, log.Test())
+		eng.SetRecorder(rec)
+		eng.SetReplayer(rep)
+		app, err := eng.NewApp(addr.String(), code, false)
+		if err != nil {
+			t.Fatalf("new app fail: err: %v", err)
+		}
+		ret, err := eng.Run(app, []byte(input))
+		if err != nil {
+			return "", eng.GasUsed(), err
+		}
+		out, _ := app.VM.VMemory().GetString(ret)
+		return string(out), eng.GasUsed(), nil
+	}
+
+	buf := new(bytes.Buffer)
+	Inject(&ctx, cState)
+	want, wantGas, err := run(cState, vm.NewRecorder(buf), nil, "a|a")
+	if err != nil {
+		t.Fatalf("record run fail: %v", err)
+	}
+	if want != cState.GetBalance(balanceAddr).String() {
+		t.Fatalf("unexpected balance: %s", want)
+	}
+	recording := buf.Bytes()
+	t.Logf("recording:\n%s", recording)
+
+	// replay without any state
+	Inject(&ctx, nil)
+	rep, err := vm.NewReplayer(bytes.NewReader(recording))
+	if err != nil {
+		t.Fatalf("read recording fail: %v", err)
+	}
+	got, gotGas, err := run(rep, nil, rep, "a|a")
+	if err != nil {
+		t.Fatalf("replay fail: %v, divergence: %v", err, rep.Divergence())
+	}
+	if got != want || gotGas != wantGas || rep.Remaining() != 0 {
+		t.Fatalf("replay mismatch: ret %s/%s gas %d/%d remaining %d", got, want, gotGas, wantGas, rep.Remaining())
+	}
+
+	rep, _ = vm.NewReplayer(bytes.NewReader(recording))
+	if _, _, err := run(rep, nil, rep, "b|b"); err != vm.ErrReplayDiverged {
+		t.Fatalf("expected divergence, got %v", err)
+	}
+	if d := rep.Divergence(); d == nil || d.Want == nil || d.Want.Seq != 1 {
+		t.Fatalf("unexpected divergence: %v", d)
+	}
+	Inject(&ctx, cState)
+}
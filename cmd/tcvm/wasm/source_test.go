@@ -0,0 +1,89 @@
+package wasm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestCodeOffset(t *testing.T) {
+	files, _ := filepath.Glob("../../../testdata/*.wasm")
+	for _, file := range files {
+		code, err := ioutil.ReadFile(file)
+		if err != nil || !vm.IsWasmContract(code) {
+			continue
+		}
+		addr := types.BytesToAddress([]byte{200})
+		contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), nil, 0)
+		eng := vm.NewEngine(contract, 1000000, cState, log.Test())
+		app, err := vm.NewApp(file, code, eng, false, log.Test())
+		if err != nil {
+			t.Logf("%s: new app fail: %v", file, err)
+			continue
+		}
+		for i, fn := range app.Module.FunctionIndexSpace {
+			if fn.IsHost() {
+				continue
+			}
+			if _, ok := app.CodeOffset(int64(i), 0); !ok {
+				t.Fatalf("%s: no code offset for func %d(%s)", file, i, fn.Name)
+			}
+		}
+	}
+}
+
+func TestTrapAndGasProfile(t *testing.T) {
+	wasmFile := "../../../testdata/token.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{201})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	cState.SetCode(addr, code)
+
+	contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+	contract.CodeAddr = &addr
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+	Inject(&ctx, cState)
+
+	// out of gas half way
+	eng := vm.NewEngine(contract, 200, cState, log.Test())
+	profile := vm.NewGasProfile()
+	eng.SetGasProfile(profile)
+	app, err := eng.NewApp(addr.String(), nil, false)
+	if err != nil {
+		t.Logf("new app fail: err: %v", err)
+		return
+	}
+	_, err = eng.Run(app, []byte("a|a"))
+	if err == nil {
+		t.Fatalf("run with 200 gas should fail")
+	}
+	trap := eng.Trap()
+	if trap == nil || !strings.Contains(trap.Where, "+0x") {
+		t.Fatalf("unexpected trap: %v", trap)
+	}
+	t.Logf("trap: %s", trap)
+
+	var total uint64
+	for _, e := range profile.Entries() {
+		total += e.Gas
+	}
+	if total != eng.GasUsed() {
+		t.Fatalf("gas profile total %d, gas used %d", total, eng.GasUsed())
+	}
+	profile.WriteTo(os.Stdout)
+}
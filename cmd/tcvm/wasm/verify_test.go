@@ -0,0 +1,76 @@
+package wasm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/types"
+	"github.com/xunleichain/tc-wasm/vm"
+)
+
+func TestVerifyNative(t *testing.T) {
+	wasmFile := "../../../testdata/token.wasm"
+	code, err := ioutil.ReadFile(wasmFile)
+	if err != nil {
+		t.Logf("read wasm code fail: %v", err)
+		return
+	}
+	addr := types.BytesToAddress([]byte{206})
+	cState.AddBalance(addr, big.NewInt(int64(10000)))
+	cState.SetCode(addr, code)
+
+	ctx := Context{
+		Time:        new(big.Int).SetUint64(ctxTime),
+		Token:       addr,
+		BlockNumber: big.NewInt(3456),
+	}
+	Inject(&ctx, cState)
+
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var reports []*vm.VerifyReport
+	cfg := vm.DefaultAotConfig()
+	cfg.Root = dir
+	cfg.VerifyRate = 1
+	cfg.OnMismatch = func(r *vm.VerifyReport) { reports = append(reports, r) }
+	aots, err := vm.StartAotService(cfg)
+	if err != nil {
+		t.Fatalf("start AotService fail: %v", err)
+	}
+	defer aots.Stop()
+
+	deadline := time.Now().Add(time.Minute)
+	for aots.Stats().Verified == 0 {
+		if time.Now().After(deadline) {
+			t.Skipf("no native code: %+v", aots.Stats())
+		}
+		contract := vm.NewContract(cAddr.Bytes(), addr.Bytes(), big.NewInt(100), 0)
+		contract.CodeAddr = &addr
+		eng := vm.NewEngine(contract, 100000, cState, log.Test())
+		eng.SetAotService(aots)
+		app, err := eng.NewApp(addr.String(), nil, false)
+		if err != nil {
+			t.Fatalf("new app fail: err: %v", err)
+		}
+		if _, err := eng.Run(app, []byte("a|a")); err != nil {
+			t.Fatalf("run fail: %v", err)
+		}
+
+		st := aots.Stats()
+		if st.Done > 0 && st.Compiling == 0 && st.Queued == 0 && vm.GetNative(app) == nil {
+			t.Skipf("AOT compile failed, stats: %+v", st)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("native code differs from the interpreter: %s", reports[0])
+	}
+}
@@ -0,0 +1,130 @@
+package bn256
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	g1 := new(G1).ScalarBaseMult(big.NewInt(1))
+	if _, ok := new(G1).Unmarshal(g1.Marshal()); !ok {
+		t.Fatal("G1 generator not on the curve")
+	}
+	if !new(G1).ScalarMult(g1, Order).p.IsInfinity() {
+		t.Fatal("G1 generator not of order Order")
+	}
+	g2 := new(G2).ScalarBaseMult(big.NewInt(1))
+	if _, ok := new(G2).Unmarshal(g2.Marshal()); !ok {
+		t.Fatal("G2 generator not in G2")
+	}
+
+	// 2·(1, 2), from the test vectors of the precompiled contracts
+	want, _ := hex.DecodeString("030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4")
+	if got := new(G1).Add(g1, g1).Marshal(); !bytes.Equal(got, want) {
+		t.Fatalf("2·G1 %x", got)
+	}
+	if got := new(G1).ScalarBaseMult(big.NewInt(2)).Marshal(); !bytes.Equal(got, want) {
+		t.Fatalf("2·G1 %x", got)
+	}
+}
+
+func TestBilinearity(t *testing.T) {
+	a, b := big.NewInt(0x1234567), new(big.Int).Sub(Order, big.NewInt(0x7654321))
+	ab := new(big.Int).Mul(a, b)
+	ab.Mod(ab, Order)
+
+	g1 := new(G1).ScalarBaseMult(big.NewInt(1))
+	g2 := new(G2).ScalarBaseMult(big.NewInt(1))
+	e := Pair(g1, g2)
+	if e.p.IsOne() {
+		t.Fatal("degenerate pairing")
+	}
+
+	e1 := Pair(new(G1).ScalarBaseMult(a), new(G2).ScalarBaseMult(b)).Marshal()
+	e2 := Pair(new(G1).ScalarBaseMult(ab), g2).Marshal()
+	e3 := new(GT).ScalarMult(e, ab).Marshal()
+	if !bytes.Equal(e1, e2) || !bytes.Equal(e1, e3) {
+		t.Fatal("pairing not bilinear")
+	}
+}
+
+func TestPairingCheck(t *testing.T) {
+	g1 := new(G1).ScalarBaseMult(big.NewInt(3))
+	g2 := new(G2).ScalarBaseMult(big.NewInt(5))
+	neg := new(G1).Neg(new(G1).ScalarBaseMult(big.NewInt(15)))
+	one := new(G2).ScalarBaseMult(big.NewInt(1))
+	if !PairingCheck([]*G1{g1, neg}, []*G2{g2, one}) {
+		t.Fatal("e(3·G1, 5·G2)·e(-15·G1, G2) != 1")
+	}
+	if PairingCheck([]*G1{g1, g1}, []*G2{g2, one}) {
+		t.Fatal("e(3·G1, 5·G2)·e(3·G1, G2) == 1")
+	}
+	inf, _ := new(G1).Unmarshal(make([]byte, 64))
+	if !PairingCheck([]*G1{inf}, []*G2{g2}) || !PairingCheck(nil, nil) {
+		t.Fatal("empty product != 1")
+	}
+}
+
+func TestUnmarshalChecks(t *testing.T) {
+	m := new(G1).ScalarBaseMult(big.NewInt(1)).Marshal()
+	copy(m[:32], p.Bytes())
+	m[31]++ // x = p+1 = 1 mod p
+	if _, ok := new(G1).Unmarshal(m); ok {
+		t.Fatal("G1 coordinate >= p")
+	}
+
+	// a point of the twist out of G2: the cofactor of the twist is not one
+	x := &gfP2{big.NewInt(0), big.NewInt(1)}
+	for {
+		y2 := newGFp2(nil).Square(x, nil)
+		y2.Mul(y2, x, nil)
+		y2.Add(y2, twistB)
+		y2.Minimal()
+		if y := sqrtGFp2(y2); y != nil {
+			pt := &G2{&twistPoint{x, y, newGFp2(nil).SetOne(), newGFp2(nil).SetOne()}}
+			if !pt.p.IsOnCurve() {
+				t.Fatal("bad test point")
+			}
+			if _, ok := new(G2).Unmarshal(pt.Marshal()); ok {
+				t.Fatal("point out of G2 unmarshaled")
+			}
+			return
+		}
+		x.y.Add(x.y, big.NewInt(1))
+	}
+}
+
+// sqrtGFp2 returns a square root of a in GF(p²) if a has one and its
+// conjugate norm is a square, enough for the test.
+func sqrtGFp2(a *gfP2) *gfP2 {
+	// a = y + xi, with p = 3 mod 4: sqrt(a) = sqrt((y + n)/2) + x/(2·sqrt((y + n)/2))·i
+	// where n = sqrt(x² + y²)
+	exp := new(big.Int).Add(p, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	sqrt := func(v *big.Int) *big.Int {
+		r := new(big.Int).Exp(v, exp, p)
+		if new(big.Int).Mod(new(big.Int).Mul(r, r), p).Cmp(new(big.Int).Mod(v, p)) != 0 {
+			return nil
+		}
+		return r
+	}
+	norm := new(big.Int).Add(new(big.Int).Mul(a.x, a.x), new(big.Int).Mul(a.y, a.y))
+	n := sqrt(norm.Mod(norm, p))
+	if n == nil {
+		return nil
+	}
+	half := new(big.Int).ModInverse(big.NewInt(2), p)
+	for _, s := range []*big.Int{n, new(big.Int).Neg(n)} {
+		v := new(big.Int).Add(a.y, s)
+		v.Mul(v, half).Mod(v, p)
+		r := sqrt(v)
+		if r == nil || r.Sign() == 0 {
+			continue
+		}
+		im := new(big.Int).Mul(a.x, new(big.Int).ModInverse(new(big.Int).Lsh(r, 1), p))
+		return &gfP2{im.Mod(im, p), r}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 The go-interpreter Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+func (vm *VM) i32Const() {
+	vm.pushUint32(vm.fetchUint32())
+}
+
+func (vm *VM) i64Const() {
+	vm.pushUint64(vm.fetchUint64())
+}
+
+func (vm *VM) f32Const() {
+	vm.pushFloat32(vm.fetchFloat32())
+}
+
+func (vm *VM) f64Const() {
+	vm.pushFloat64(vm.fetchFloat64())
+}
@@ -0,0 +1,814 @@
+// Copyright 2017 The go-interpreter Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exec provides functions for executing WebAssembly bytecode.
+package exec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/go-interpreter/wagon/disasm"
+	"github.com/go-interpreter/wagon/exec/internal/compile"
+	"github.com/go-interpreter/wagon/memory"
+	"github.com/go-interpreter/wagon/wasm"
+	ops "github.com/go-interpreter/wagon/wasm/operators"
+)
+
+var (
+	// ErrMultipleLinearMemories is returned by (*VM).NewVM when the module
+	// has more then one entries in the linear memory space.
+	ErrMultipleLinearMemories = errors.New("exec: more than one linear memories in module")
+	// ErrInvalidArgumentCount is returned by (*VM).ExecCode when an invalid
+	// number of arguments to the WebAssembly function are passed to it.
+	ErrInvalidArgumentCount = errors.New("exec: invalid number of arguments to function")
+)
+
+// InvalidReturnTypeError is returned by (*VM).ExecCode when the module
+// specifies an invalid return type value for the executed function.
+type InvalidReturnTypeError int8
+
+func (e InvalidReturnTypeError) Error() string {
+	return fmt.Sprintf("Function has invalid return value_type: %d", int8(e))
+}
+
+// InvalidFunctionIndexError is returned by (*VM).ExecCode when the function
+// index provided is invalid.
+type InvalidFunctionIndexError int64
+
+func (e InvalidFunctionIndexError) Error() string {
+	return fmt.Sprintf("Invalid index to function index space: %d", int64(e))
+}
+
+type Backend interface {
+	IsTracing() bool
+	Trace(fmt string, v ...interface{})
+	UseGas(gas uint64) bool
+	Caller() []byte
+}
+
+type FeeBackend interface {
+	AddFee(fee uint64)
+	GetFee() uint64
+	SetFee(fee uint64)
+	CalFee(realCost uint64, currentFee uint64)
+}
+
+type context struct {
+	stack   []uint64
+	locals  []uint64
+	code    []byte
+	asm     []asmBlock
+	pc      int64
+	curFunc int64
+	entry   bool // no instruction of the frame has been executed yet
+}
+
+// VM is the execution context for executing WebAssembly bytecode.
+type VM struct {
+	ctx context
+
+	module  *wasm.Module
+	globals []uint64
+	funcs   []function
+	//memory  []byte
+	mem *memory.MemManager
+
+	funcTable [256]func()
+	opSet     []operation
+
+	ops Backend
+
+	// RecoverPanic controls whether the `ExecCode` method
+	// recovers from a panic and returns it as an error
+	// instead.
+	// A panic can occur either when executing an invalid VM
+	// or encountering an invalid instruction, e.g. `unreachable`.
+	RecoverPanic bool
+
+	abort bool // Flag for host functions to terminate execution
+
+	nativeBackend *nativeCompiler
+}
+
+func CeateVMemory(module *wasm.Module) (*memory.MemManager, error) {
+	exportEntry, ok := module.Export.Entries["__heap_base"]
+	if !ok {
+		return nil, errors.New("invalid memory no __heap_base")
+	}
+	if exportEntry.Kind != wasm.ExternalGlobal {
+		return nil, errors.New("invalid type of __heap_base")
+	}
+	globalEntry := module.GetGlobal(int(exportEntry.Index))
+	valTmp, err := module.ExecInitExpr(globalEntry.Init)
+	if err != nil {
+		return nil, err
+	}
+	val, ok := valTmp.(int32)
+	if !ok {
+		return nil, errors.New("invalid global init expression")
+	}
+	heapBase := int(val)
+	initMemSize := int(module.Memory.Entries[0].Limits.Initial) * wasmPageSize
+	memManager, err := memory.InitMemManager(heapBase, initMemSize, 0)
+	if err != nil {
+		return nil, err
+	}
+	if module.LinearMemoryIndexSpace[0] != nil {
+		memManager.CopyDataSection(module.LinearMemoryIndexSpace[0][wasmFixedStackSize:])
+	}
+	return memManager, nil
+}
+
+func (vm *VM) VMemory() *memory.MemManager {
+	return vm.mem
+}
+
+// As per the WebAssembly spec: https://github.com/WebAssembly/design/blob/27ac254c854994103c24834a994be16f74f54186/Semantics.md#linear-memory
+const wasmPageSize = 65536 // (64 KB)
+const wasmFixedStackSize = 16 * 1024
+
+var endianess = binary.LittleEndian
+
+type config struct {
+	EnableAOT bool
+}
+
+// VMOption describes a customization that can be applied to the VM.
+type VMOption func(c *config)
+
+// EnableAOT enables ahead-of-time compilation of supported opcodes
+// into runs of native instructions, if wagon supports native compilation
+// for the current architecture.
+func EnableAOT(v bool) VMOption {
+	return func(c *config) {
+		c.EnableAOT = v
+	}
+}
+
+// NewVM creates a new VM from a given module and options. If the module defines
+// a start function, it will be executed.
+func NewVM(module *wasm.Module, ops Backend, opts ...VMOption) (*VM, error) { // CAREFUL
+	var options config
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	vmem, err := CeateVMemory(module)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := VM{
+		mem:          vmem,
+		ops:          ops,
+		RecoverPanic: true,
+	}
+
+	//if module.Memory != nil && len(module.Memory.Entries) != 0 {
+	//	if len(module.Memory.Entries) > 1 {
+	//		return nil, ErrMultipleLinearMemories
+	//	}
+	//	vm.memory = make([]byte, uint(module.Memory.Entries[0].Limits.Initial)*wasmPageSize)
+	//	copy(vm.memory, module.LinearMemoryIndexSpace[0])
+	//}
+
+	vm.funcs = make([]function, len(module.FunctionIndexSpace))
+	vm.globals = make([]uint64, len(module.GlobalIndexSpace))
+	//vm.newFuncTable()
+	vm.module = module
+	vm.opSet = opSet[0:256]
+
+	nNatives := 0
+	for i, fn := range module.FunctionIndexSpace {
+		// Skip native methods as they need not be
+		// disassembled; simply add them at the end
+		// of the `funcs` array as is, as specified
+		// in the spec. See the "host functions"
+		// section of:
+		// https://webassembly.github.io/spec/core/exec/modules.html#allocation
+		if fn.IsHost() {
+			vm.funcs[i] = goFunction{}
+			//vm.funcs[i] = goFunction{
+			//	typ: fn.Host.Type(),
+			//	val: fn.Host,
+			//}
+			nNatives++
+			continue
+		}
+
+		disassembly, err := disasm.NewDisassembly(fn, module)
+		if err != nil {
+			return nil, err
+		}
+
+		totalLocalVars := 0
+		totalLocalVars += len(fn.Sig.ParamTypes)
+		for _, entry := range fn.Body.Locals {
+			totalLocalVars += int(entry.Count)
+		}
+		code, meta := compile.Compile(disassembly.Code)
+		vm.funcs[i] = compiledFunction{
+			codeMeta:       meta,
+			code:           code,
+			branchTables:   meta.BranchTables,
+			maxDepth:       disassembly.MaxDepth,
+			totalLocalVars: totalLocalVars,
+			args:           len(fn.Sig.ParamTypes),
+			returns:        len(fn.Sig.ReturnTypes) != 0,
+		}
+	}
+
+	if err := vm.resetGlobals(); err != nil {
+		return nil, err
+	}
+
+	if module.Start != nil {
+		_, err := vm.ExecCode(int64(module.Start.Index))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//if options.EnableAOT {
+	//	supportedBackend, backend := nativeBackend()
+	//	if supportedBackend {
+	//		vm.nativeBackend = backend
+	//		if err := vm.tryNativeCompile(); err != nil {
+	//			return nil, err
+	//		}
+	//	}
+	//}
+
+	return &vm, nil
+}
+
+// Clone just copy
+func (vm *VM) Clone(ops Backend) *VM {
+	module := vm.module
+	vmem, err := CeateVMemory(module)
+	if err != nil {
+		panic(fmt.Errorf("CreateVMemory fail: %s", err))
+	}
+
+	newVM := VM{
+		mem:          vmem,
+		ops:          ops,
+		module:       vm.module,
+		globals:      make([]uint64, len(module.GlobalIndexSpace)),
+		funcs:        vm.funcs,
+		RecoverPanic: vm.RecoverPanic,
+	}
+
+	newVM.opSet = opSet[0:256]
+
+	for i, global := range module.GlobalIndexSpace {
+		val, err := module.ExecInitExpr(global.Init)
+		if err != nil {
+			panic(fmt.Sprintf("module.ExecInitExpr fail: %s", err))
+		}
+		switch v := val.(type) {
+		case int32:
+			newVM.globals[i] = uint64(v)
+		case int64:
+			newVM.globals[i] = uint64(v)
+		case float32:
+			newVM.globals[i] = uint64(math.Float32bits(v))
+		case float64:
+			newVM.globals[i] = uint64(math.Float64bits(v))
+		}
+	}
+
+	if module.Start != nil {
+		_, err := newVM.ExecCode(int64(module.Start.Index))
+		if err != nil {
+			panic("Exec Start fail")
+		}
+	}
+	return &newVM
+}
+
+func (vm *VM) resetGlobals() error {
+	for i, global := range vm.module.GlobalIndexSpace {
+		val, err := vm.module.ExecInitExpr(global.Init)
+		if err != nil {
+			return err
+		}
+		switch v := val.(type) {
+		case int32:
+			vm.globals[i] = uint64(v)
+		case int64:
+			vm.globals[i] = uint64(v)
+		case float32:
+			vm.globals[i] = uint64(math.Float32bits(v))
+		case float64:
+			vm.globals[i] = uint64(math.Float64bits(v))
+		}
+	}
+
+	return nil
+}
+
+// Memory returns the linear memory space for the VM.
+func (vm *VM) Memory() []byte {
+	//return vm.memory
+	return vm.mem.Memory
+}
+
+func (vm *VM) pushBool(v bool) {
+	if v {
+		vm.pushUint64(1)
+	} else {
+		vm.pushUint64(0)
+	}
+}
+
+func (vm *VM) fetchBool() bool {
+	return vm.fetchInt8() != 0
+}
+
+func (vm *VM) fetchInt8() int8 {
+	i := int8(vm.ctx.code[vm.ctx.pc])
+	vm.ctx.pc++
+	return i
+}
+
+func (vm *VM) fetchUint32() uint32 {
+	v := endianess.Uint32(vm.ctx.code[vm.ctx.pc:])
+	vm.ctx.pc += 4
+	return v
+}
+
+func (vm *VM) fetchInt32() int32 {
+	return int32(vm.fetchUint32())
+}
+
+func (vm *VM) fetchFloat32() float32 {
+	return math.Float32frombits(vm.fetchUint32())
+}
+
+func (vm *VM) fetchUint64() uint64 {
+	v := endianess.Uint64(vm.ctx.code[vm.ctx.pc:])
+	vm.ctx.pc += 8
+	return v
+}
+
+func (vm *VM) fetchInt64() int64 {
+	return int64(vm.fetchUint64())
+}
+
+func (vm *VM) fetchFloat64() float64 {
+	return math.Float64frombits(vm.fetchUint64())
+}
+
+func (vm *VM) popUint64() uint64 {
+	i := vm.ctx.stack[len(vm.ctx.stack)-1]
+	vm.ctx.stack = vm.ctx.stack[:len(vm.ctx.stack)-1]
+	return i
+}
+
+func (vm *VM) popInt64() int64 {
+	return int64(vm.popUint64())
+}
+
+func (vm *VM) popFloat64() float64 {
+	return math.Float64frombits(vm.popUint64())
+}
+
+func (vm *VM) popUint32() uint32 {
+	return uint32(vm.popUint64())
+}
+
+func (vm *VM) popInt32() int32 {
+	return int32(vm.popUint32())
+}
+
+func (vm *VM) popFloat32() float32 {
+	return math.Float32frombits(vm.popUint32())
+}
+
+func (vm *VM) pushUint64(i uint64) {
+	if debugStackDepth {
+		if len(vm.ctx.stack) >= cap(vm.ctx.stack) {
+			panic("stack exceeding max depth: " + fmt.Sprintf("len=%d,cap=%d", len(vm.ctx.stack), cap(vm.ctx.stack)))
+		}
+	}
+	vm.ctx.stack = append(vm.ctx.stack, i)
+}
+
+func (vm *VM) pushInt64(i int64) {
+	vm.pushUint64(uint64(i))
+}
+
+func (vm *VM) pushFloat64(f float64) {
+	vm.pushUint64(math.Float64bits(f))
+}
+
+func (vm *VM) pushUint32(i uint32) {
+	vm.pushUint64(uint64(i))
+}
+
+func (vm *VM) pushInt32(i int32) {
+	vm.pushUint64(uint64(i))
+}
+
+func (vm *VM) pushFloat32(f float32) {
+	vm.pushUint32(math.Float32bits(f))
+}
+
+func (vm *VM) prefetchInt8() int8 {
+	return int8(vm.ctx.code[vm.ctx.pc])
+}
+
+func (vm *VM) prefetchUint32() uint32 {
+	return endianess.Uint32(vm.ctx.code[vm.ctx.pc:])
+}
+
+func (vm *VM) prefetchInt32() int32 {
+	return int32(vm.prefetchUint32())
+}
+
+func (vm *VM) prefetchUint64() uint64 {
+	return endianess.Uint64(vm.ctx.code[vm.ctx.pc:])
+}
+
+func (vm *VM) prefetchInt64() int64 {
+	return int64(vm.prefetchUint64())
+}
+
+func (vm *VM) backUint64(n int) uint64 {
+	return vm.ctx.stack[len(vm.ctx.stack)-1-n]
+}
+
+func (vm *VM) stackLen() int {
+	return len(vm.ctx.stack)
+}
+
+// ExecCode calls the function with the given index and arguments.
+// fnIndex should be a valid index into the function index space of
+// the VM's module.
+func (vm *VM) ExecCode(fnIndex int64, args ...uint64) (rtrn interface{}, err error) {
+	// If used as a library, client code should set vm.RecoverPanic to true
+	// in order to have an error returned.
+	if vm.RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				switch e := r.(type) {
+				case error:
+					err = e
+				default:
+					err = fmt.Errorf("exec: %v", e)
+				}
+			}
+		}()
+	}
+	if int(fnIndex) > len(vm.funcs) {
+		return nil, InvalidFunctionIndexError(fnIndex)
+	}
+	if len(vm.module.GetFunction(int(fnIndex)).Sig.ParamTypes) != len(args) {
+		return nil, ErrInvalidArgumentCount
+	}
+	compiled, ok := vm.funcs[fnIndex].(compiledFunction)
+	if !ok {
+		panic(fmt.Sprintf("exec: function at index %d is not a compiled function", fnIndex))
+	}
+
+	depth := compiled.maxDepth + 1
+	if cap(vm.ctx.stack) < depth {
+		vm.ctx.stack = make([]uint64, 0, depth)
+	} else {
+		vm.ctx.stack = vm.ctx.stack[:0]
+	}
+
+	vm.ctx.locals = make([]uint64, compiled.totalLocalVars)
+	vm.ctx.pc = 0
+	vm.ctx.entry = true
+	vm.ctx.code = compiled.code
+	vm.ctx.asm = compiled.asm
+	vm.ctx.curFunc = fnIndex
+
+	for i, arg := range args {
+		vm.ctx.locals[i] = arg
+	}
+
+	res := vm.execCode(compiled)
+	if compiled.returns {
+		rtrnType := vm.module.GetFunction(int(fnIndex)).Sig.ReturnTypes[0]
+		switch rtrnType {
+		case wasm.ValueTypeI32:
+			rtrn = uint32(res)
+		case wasm.ValueTypeI64:
+			rtrn = uint64(res)
+		case wasm.ValueTypeF32:
+			rtrn = math.Float32frombits(uint32(res))
+		case wasm.ValueTypeF64:
+			rtrn = math.Float64frombits(res)
+		default:
+			return nil, InvalidReturnTypeError(rtrnType)
+		}
+	}
+
+	return rtrn, nil
+}
+
+// PreRun prepare to run
+func (vm *VM) PreRun(fnIndex int64, args ...uint64) error {
+	if int(fnIndex) > len(vm.funcs) {
+		return InvalidFunctionIndexError(fnIndex)
+	}
+	if len(vm.module.GetFunction(int(fnIndex)).Sig.ParamTypes) != len(args) {
+		return ErrInvalidArgumentCount
+	}
+	compiled, ok := vm.funcs[fnIndex].(compiledFunction)
+	if !ok {
+		return fmt.Errorf("exec: function at index %d is not a compiled function", fnIndex)
+	}
+	if len(vm.ctx.stack) < compiled.maxDepth {
+		vm.ctx.stack = make([]uint64, 0, compiled.maxDepth)
+	}
+	vm.ctx.locals = make([]uint64, compiled.totalLocalVars)
+	vm.ctx.pc = 0
+	vm.ctx.entry = true
+	vm.ctx.code = compiled.code
+	vm.ctx.curFunc = fnIndex
+
+	for i, arg := range args {
+		vm.ctx.locals[i] = arg
+	}
+
+	return nil
+}
+
+// Run execute code.
+func (vm *VM) Run() (rtrn interface{}, err error) {
+	if vm.RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				switch e := r.(type) {
+				case error:
+					err = e
+				default:
+					err = fmt.Errorf("exec: %v", e)
+				}
+			}
+		}()
+	}
+
+	fnIndex := vm.ctx.curFunc
+	compiled := vm.funcs[fnIndex].(compiledFunction)
+	res := vm.execCode(compiled)
+	vm.mem.Release()
+
+	if compiled.returns {
+		rtrnType := vm.module.GetFunction(int(fnIndex)).Sig.ReturnTypes[0]
+		switch rtrnType {
+		case wasm.ValueTypeI32:
+			rtrn = uint32(res)
+		case wasm.ValueTypeI64:
+			rtrn = uint64(res)
+		case wasm.ValueTypeF32:
+			rtrn = math.Float32frombits(uint32(res))
+		case wasm.ValueTypeF64:
+			rtrn = math.Float64frombits(res)
+		default:
+			return nil, InvalidReturnTypeError(rtrnType)
+		}
+	}
+
+	return rtrn, err
+}
+
+func (vm *VM) execCode(compiled compiledFunction) uint64 {
+	feeOps, ok := vm.ops.(FeeBackend)
+	if !ok {
+		panic("[vm] execCode: ops must implements FeeBackend")
+	}
+outer:
+	for int(vm.ctx.pc) < len(vm.ctx.code) && !vm.abort {
+		vm.trace()
+		vm.ctx.entry = false
+		op := vm.ctx.code[vm.ctx.pc]
+		vm.ctx.pc++
+
+		preFee := feeOps.GetFee()
+		switch op {
+		case ops.Return:
+			break outer
+		case compile.OpJmp:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			vm.ctx.pc = vm.fetchInt64()
+			continue
+		case compile.OpJmpZ:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			target := vm.fetchInt64()
+			if vm.popUint32() == 0 {
+				vm.ctx.pc = target
+				continue
+			}
+		case compile.OpJmpNz:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			target := vm.fetchInt64()
+			preserveTop := vm.fetchBool()
+			discard := vm.fetchInt64()
+			if vm.popUint32() != 0 {
+				vm.ctx.pc = target
+				var top uint64
+				if preserveTop {
+					top = vm.ctx.stack[len(vm.ctx.stack)-1]
+				}
+				vm.ctx.stack = vm.ctx.stack[:len(vm.ctx.stack)-int(discard)]
+				if preserveTop {
+					vm.pushUint64(top)
+				}
+				continue
+			}
+		case ops.BrTable:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			index := vm.fetchInt64()
+			label := vm.popInt32()
+			cf, ok := vm.funcs[vm.ctx.curFunc].(compiledFunction)
+			if !ok {
+				panic(fmt.Sprintf("exec: function at index %d is not a compiled function", vm.ctx.curFunc))
+			}
+			table := cf.branchTables[index]
+			var target compile.Target
+			if label >= 0 && label < int32(len(table.Targets)) {
+				target = table.Targets[int32(label)]
+			} else {
+				target = table.DefaultTarget
+			}
+
+			if target.Return {
+				break outer
+			}
+			vm.ctx.pc = target.Addr
+			var top uint64
+			if target.PreserveTop {
+				top = vm.ctx.stack[len(vm.ctx.stack)-1]
+			}
+			vm.ctx.stack = vm.ctx.stack[:len(vm.ctx.stack)-int(target.Discard)]
+			if target.PreserveTop {
+				vm.pushUint64(top)
+			}
+			continue
+		case compile.OpDiscard:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			place := vm.fetchInt64()
+			vm.ctx.stack = vm.ctx.stack[:len(vm.ctx.stack)-int(place)]
+		case compile.OpDiscardPreserveTop:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			top := vm.ctx.stack[len(vm.ctx.stack)-1]
+			place := vm.fetchInt64()
+			vm.ctx.stack = vm.ctx.stack[:len(vm.ctx.stack)-int(place)]
+			vm.pushUint64(top)
+
+		case ops.WagonNativeExec:
+			if !vm.ops.UseGas(GasQuickStep) {
+				panic("[vm] execCode: OutOfGas")
+			}
+			i := vm.fetchUint32()
+			vm.nativeCodeInvocation(i)
+		default:
+			//vm.funcTable[op]()
+			operation := vm.opSet[op]
+			if operation.gasCost == nil || operation.execute == nil {
+				panic(fmt.Sprintf("[vm] operation(%s) Forbiden!!", ops.OpSignature(op)))
+			}
+
+			cost, err := operation.gasCost(vm)
+			if err != nil {
+				feeOps.SetFee(preFee)
+				panic(fmt.Sprintf("[vm] execCode: calc gas fail: %s", err))
+			}
+			if !vm.ops.UseGas(cost) {
+				currentFee := feeOps.GetFee() - preFee
+				realCost := cost - currentFee
+				feeOps.CalFee(realCost, currentFee)
+				panic("[vm] execCode: OutOfGas")
+			}
+			if vm.ops.IsTracing() {
+				vm.ops.Trace(fmt.Sprintf("debug op:%d, name:%s, gas:%d\n", op, ops.OpSignature(op), cost))
+			}
+			operation.execute(vm)
+		}
+	}
+
+	if compiled.returns {
+		return vm.ctx.stack[len(vm.ctx.stack)-1]
+	}
+	return 0
+}
+
+// Restart readies the VM for another run.
+func (vm *VM) Restart() {
+	vm.resetGlobals()
+	vm.ctx.locals = make([]uint64, 0)
+	vm.abort = false
+}
+
+// Close frees any resources managed by the VM.
+func (vm *VM) Close() error {
+	vm.abort = true // prevents further use.
+	if vm.nativeBackend != nil {
+		if err := vm.nativeBackend.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vm *VM) trace() {
+	if vm.ops.IsTracing() {
+		op := vm.ctx.code[vm.ctx.pc]
+		buf := bytes.NewBuffer(nil)
+		buf.WriteString(fmt.Sprintf("pc:%d, op:%s\n", vm.ctx.pc, ops.OpSignature(op)))
+		buf.WriteString(fmt.Sprintf("stack: len=%d, vals=%v\n", len(vm.ctx.stack), vm.ctx.stack))
+		//buf.WriteString((fmt.Sprintf("mem: %s\n", vm.VMemory().String())))
+		vm.ops.Trace(buf.String())
+		//vm.ops.Trace(fmt.Sprintf("pc:%d, op:%s", vm.ctx.pc, ops.OpSignature(op)))
+		//vm.ops.Trace(fmt.Sprintf("stack: len=%d, vals=%v", len(vm.ctx.stack), vm.ctx.stack))
+		//vm.ops.Trace(fmt.Sprintf("mem: %s", vm.VMemory().String()))
+	}
+}
+
+// Process is a proxy passed to host functions in order to access
+// things such as memory and control.
+type Process struct {
+	vm *VM
+}
+
+// NewProcess creates a VM interface object for host functions
+func NewProcess(vm *VM) *Process {
+	return &Process{vm: vm}
+}
+
+// ReadAt implements the ReaderAt interface: it copies into p
+// the content of memory at offset off.
+func (proc *Process) ReadAt(p []byte, off int64) (int, error) {
+	mem := proc.vm.Memory()
+
+	var length int
+	if len(mem) < len(p)+int(off) {
+		length = len(mem) - int(off)
+	} else {
+		length = len(p)
+	}
+
+	copy(p, mem[off:off+int64(length)])
+
+	var err error
+	if length < len(p) {
+		err = io.ErrShortBuffer
+	}
+
+	return length, err
+}
+
+// WriteAt implements the WriterAt interface: it writes the content of p
+// into the VM memory at offset off.
+func (proc *Process) WriteAt(p []byte, off int64) (int, error) {
+	mem := proc.vm.Memory()
+
+	var length int
+	if len(mem) < len(p)+int(off) {
+		length = len(mem) - int(off)
+	} else {
+		length = len(p)
+	}
+
+	copy(mem[off:], p[:length])
+
+	var err error
+	if length < len(p) {
+		err = io.ErrShortWrite
+	}
+
+	return length, err
+}
+
+// MemSize returns the current allocated memory size in bytes.
+func (proc *Process) MemSize() int {
+	return len(proc.vm.Memory())
+}
+
+// Terminate stops the execution of the current module.
+func (proc *Process) Terminate() {
+	proc.vm.abort = true
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The go-interpreter Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+// The accessors below expose the execution context of the interpreter to
+// debuggers and profilers. The returned slices are owned by the VM and are
+// only valid until it executes the next instruction.
+
+// CurrentFrame returns the function index and the bytecode pc of the frame
+// being executed. ok is false if the VM is not running any code.
+func (vm *VM) CurrentFrame() (fnIndex int64, pc int64, ok bool) {
+	if vm.ctx.code == nil {
+		return 0, 0, false
+	}
+	return vm.ctx.curFunc, vm.ctx.pc, true
+}
+
+// FrameEntry reports whether the frame being executed was just entered by a
+// call and has not executed any instruction yet. Unlike a zero pc, it is not
+// set again by a branch back to the start of the function body.
+func (vm *VM) FrameEntry() bool {
+	return vm.ctx.entry
+}
+
+// FrameValues returns the operand stack and the locals of the frame being
+// executed.
+func (vm *VM) FrameValues() (stack, locals []uint64) {
+	return vm.ctx.stack, vm.ctx.locals
+}
+
+// Globals returns the values of the globals of the module.
+func (vm *VM) Globals() []uint64 {
+	return vm.globals
+}
+
+// CompiledCode returns the bytecode run by the interpreter for the function
+// at index, or nil for host functions and invalid indexes.
+func (vm *VM) CompiledCode(index int64) []byte {
+	if index < 0 || index >= int64(len(vm.funcs)) {
+		return nil
+	}
+	if compiled, ok := vm.funcs[index].(compiledFunction); ok {
+		return compiled.code
+	}
+	return nil
+}
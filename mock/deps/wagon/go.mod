@@ -0,0 +1,7 @@
+module github.com/go-interpreter/wagon
+
+require (
+	github.com/edsrzf/mmap-go v1.0.0
+	github.com/twitchyliquid64/golang-asm v0.0.0-20190126203739-365674df15fc
+	golang.org/x/sys v0.0.0-20190306220234-b354f8bf4d9e // indirect
+)
@@ -1,13 +1,14 @@
 package vm
 
 import (
-	"bytes"
-	"crypto/md5"
+	"container/heap"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,22 +22,148 @@ type AotService struct {
 	path        string
 	keepCSource bool
 	exit        chan struct{}
-	refresh     chan *APP
+	done        chan struct{}
+	stopOnce    sync.Once
+	workers     int
+	queueSize   int
+	wg          sync.WaitGroup
+	verifyRate  float64
+	onMismatch  func(r *VerifyReport)
+
+	queue     aotQueue
+	queued    map[string]*aotRequest
+	ready     map[string]chan struct{} // closed once the queued artifact is done
+	seq       uint64
+	cond      *sync.Cond
+	stats     AotStats
+	version   string
+	compiler  string
+	toolchain AotToolchain
+	hmacKey   []byte
+	sandbox   *SandboxConfig
+	refs      map[string]map[string]struct{}
+	black     map[string]*AotBlackEntry
+	retryMin  time.Duration
+	retryMax  time.Duration
+	succ      map[string]*Native
+	onDelete  map[string]*Native
+	evict     AotEvictConfig
+	pinned    map[string]struct{}
+	lock      sync.Mutex
+	logger    log.Logger
+}
+
+// aotCodegenVersion identifies the C code generator and runtime the
+// artifacts are built with; artifacts of other versions are not reused.
+const aotCodegenVersion = "cgen2"
 
-	black    map[string]struct{}
-	succ     map[string]*Native
-	onDelete map[string]*Native
-	lock     sync.Mutex
-	logger   log.Logger
+// AotStats are the counters of an AotService.
+type AotStats struct {
+	Queued    int    // contracts waiting for a worker
+	Compiling int    // contracts being compiled or loaded
+	Dropped   uint64 // requests dropped because the queue was full
+	Done      uint64 // contracts compiled or loaded, or failed
+
+	Verified   uint64 // runs checked against the interpreter
+	Mismatched uint64 // runs where native code and interpreter differed
 }
 
 // Env Variable
 const TCVM_AOTS_ENABLE = "TCVM_AOTS_ENABLE"
 const TCVM_AOTS_ROOT = "TCVM_AOTS_ROOT"
 const TCVM_AOTS_KEEP_CSOURCE = "TCVM_AOTS_KEEP_CSOURCE"
+const TCVM_AOTS_WORKERS = "TCVM_AOTS_WORKERS"
+const TCVM_AOTS_VERIFY_RATE = "TCVM_AOTS_VERIFY_RATE"
+const TCVM_AOTS_CC = "TCVM_AOTS_CC"
+const TCVM_AOTS_OPT = "TCVM_AOTS_OPT"
+const TCVM_AOTS_CFLAGS = "TCVM_AOTS_CFLAGS"
+const TCVM_AOTS_HMAC_KEY_FILE = "TCVM_AOTS_HMAC_KEY_FILE"
+const TCVM_AOTS_SANDBOX = "TCVM_AOTS_SANDBOX"
+
+// AotConfig configures an AotService.
+type AotConfig struct {
+	Root        string // directory of the compiled contracts
+	KeepCSource bool   // keep the generated C source next to the .so
+	Workers     int    // contracts compiled in parallel
+	QueueSize   int    // contracts waiting to be compiled, more are dropped
+	Logger      log.Logger
+	Evict       AotEvictConfig
+	Toolchain   AotToolchain // probed by StartAotService
+
+	// HMACKeyFile is a node key, readable by the node user only, signing
+	// the compiled libraries so that they are not loaded if replaced
+	// together with their digest.
+	HMACKeyFile string
+
+	// Sandbox runs the native code in helper processes, see SandboxConfig.
+	Sandbox *SandboxConfig
+
+	// VerifyRate is the fraction of top level runs with native code that are
+	// also run on the interpreter and compared, see Engine.Run. A mismatch
+	// blacklists the native code and is passed to OnMismatch.
+	VerifyRate float64
+	OnMismatch func(r *VerifyReport)
+
+	// RetryMin and RetryMax bound the backoff of retrying artifacts that
+	// failed to build or load, doubling from RetryMin per failure.
+	RetryMin time.Duration
+	RetryMax time.Duration
+}
+
+// DefaultAotConfig --
+func DefaultAotConfig() AotConfig {
+	return AotConfig{
+		Root:        "/tmp/aots",
+		KeepCSource: true,
+		Workers:     2,
+		QueueSize:   64,
+		Evict:       DefaultAotEvictConfig(),
+		Toolchain:   DefaultAotToolchain(),
+		RetryMin:    time.Minute,
+		RetryMax:    24 * time.Hour,
+	}
+}
+
+// AotConfigFromEnv reads the config from TCVM_AOTS_ROOT,
+// TCVM_AOTS_KEEP_CSOURCE, TCVM_AOTS_WORKERS, TCVM_AOTS_VERIFY_RATE,
+// TCVM_AOTS_CC, TCVM_AOTS_OPT, TCVM_AOTS_CFLAGS, TCVM_AOTS_HMAC_KEY_FILE and
+// TCVM_AOTS_SANDBOX, and reports whether TCVM_AOTS_ENABLE is "1".
+// TCVM_AOTS_SANDBOX is "1" for the default sandbox or the path of the helper.
+func AotConfigFromEnv() (AotConfig, bool) {
+	cfg := DefaultAotConfig()
+	if path := os.Getenv(TCVM_AOTS_ROOT); path != "" {
+		cfg.Root = path
+	}
+	if os.Getenv(TCVM_AOTS_KEEP_CSOURCE) == "0" {
+		cfg.KeepCSource = false
+	}
+	if n, err := strconv.Atoi(os.Getenv(TCVM_AOTS_WORKERS)); err == nil && n > 0 {
+		cfg.Workers = n
+	}
+	if rate, err := strconv.ParseFloat(os.Getenv(TCVM_AOTS_VERIFY_RATE), 64); err == nil {
+		cfg.VerifyRate = rate
+	}
+	if cc := os.Getenv(TCVM_AOTS_CC); cc != "" {
+		cfg.Toolchain.CC = cc
+	}
+	if opt := os.Getenv(TCVM_AOTS_OPT); opt != "" {
+		cfg.Toolchain.OptLevel = opt
+	}
+	cfg.Toolchain.Flags = strings.Fields(os.Getenv(TCVM_AOTS_CFLAGS))
+	cfg.HMACKeyFile = os.Getenv(TCVM_AOTS_HMAC_KEY_FILE)
+	if helper := os.Getenv(TCVM_AOTS_SANDBOX); helper != "" && helper != "0" {
+		cfg.Sandbox = &SandboxConfig{}
+		if helper != "1" {
+			cfg.Sandbox.Helper = helper
+		}
+	}
+	return cfg, os.Getenv(TCVM_AOTS_ENABLE) == "1"
+}
 
-var aots *AotService
-var enableAots bool
+var (
+	defaultAotsLock sync.Mutex
+	defaultAots     *AotService
+)
 
 // NewAotService --
 func NewAotService(path string, keepSrouce bool) *AotService {
@@ -44,157 +171,390 @@ func NewAotService(path string, keepSrouce bool) *AotService {
 		path:        path,
 		keepCSource: keepSrouce,
 		exit:        make(chan struct{}),
-		refresh:     make(chan *APP, 4),
-		black:       make(map[string]struct{}),
+		done:        make(chan struct{}),
+		workers:     1,
+		queueSize:   4,
+		queued:      make(map[string]*aotRequest),
+		ready:       make(map[string]chan struct{}),
+		version:     aotCodegenVersion,
+		toolchain:   DefaultAotToolchain(),
+		refs:        make(map[string]map[string]struct{}),
+		black:       make(map[string]*AotBlackEntry),
+		retryMin:    time.Minute,
+		retryMax:    24 * time.Hour,
 		succ:        make(map[string]*Native, 32),
 		onDelete:    make(map[string]*Native, 8),
+		pinned:      make(map[string]struct{}),
+		logger:      log.With("mod", "aots"),
 	}
 
+	s.cond = sync.NewCond(&s.lock)
+	s.setEvictConfig(DefaultAotEvictConfig())
+
 	return &s
 }
 
+// StartAotService creates cfg.Root and starts compiling the contracts run by
+// the Engines using the service, see SetDefaultAotService and
+// Engine.SetAotService.
+func StartAotService(cfg AotConfig) (*AotService, error) {
+	if err := os.MkdirAll(cfg.Root, 0700); err != nil {
+		return nil, err
+	}
+	if err := checkRoot(cfg.Root); err != nil {
+		return nil, err
+	}
+	s := NewAotService(cfg.Root, cfg.KeepCSource)
+	if cfg.HMACKeyFile != "" {
+		key, err := readHMACKey(cfg.HMACKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		s.hmacKey = key
+	}
+	if cfg.Workers > 0 {
+		s.workers = cfg.Workers
+	}
+	if cfg.QueueSize > 0 {
+		s.queueSize = cfg.QueueSize
+	}
+	s.sandbox = cfg.Sandbox
+	if cfg.RetryMin > 0 {
+		s.retryMin = cfg.RetryMin
+	}
+	if cfg.RetryMax >= s.retryMin {
+		s.retryMax = cfg.RetryMax
+	}
+	s.verifyRate = cfg.VerifyRate
+	s.onMismatch = cfg.OnMismatch
+	if cfg.Logger != nil {
+		s.logger = cfg.Logger
+	}
+	s.setEvictConfig(cfg.Evict)
+	if cfg.Toolchain.CC != "" {
+		s.toolchain = cfg.Toolchain
+	}
+	ident, err := s.toolchain.probe(cfg.Root)
+	if err != nil {
+		return nil, err
+	}
+	s.setCompiler(ident)
+	s.logger.Info("[AotService] toolchain", "compiler", ident)
+	if err := s.loadBlacklist(); err != nil {
+		return nil, err
+	}
+
+	go s.loop()
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+	return s, nil
+}
+
+// Stop stops the service after the compiles in flight, if any, are done.
+// Requests queued but not started are dropped.
+func (s *AotService) Stop() {
+	s.stopOnce.Do(func() {
+		s.lock.Lock()
+		close(s.exit)
+		s.cond.Broadcast()
+		s.lock.Unlock()
+		<-s.done
+		s.wg.Wait()
+	})
+}
+
+// Stats returns the counters of the service.
+func (s *AotService) Stats() AotStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st := s.stats
+	st.Queued = s.queue.Len()
+	return st
+}
+
+func (s *AotService) stopped() bool {
+	select {
+	case <-s.exit:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetDefaultAotService sets the service of the Engines created afterwards;
+// nil disables AOT for them.
+func SetDefaultAotService(s *AotService) {
+	defaultAotsLock.Lock()
+	defaultAots = s
+	defaultAotsLock.Unlock()
+}
+
+// DefaultAotService --
+func DefaultAotService() *AotService {
+	defaultAotsLock.Lock()
+	defer defaultAotsLock.Unlock()
+	return defaultAots
+}
+
+// SetAotService sets the service compiling the contracts run by eng; nil
+// disables AOT.
+func (eng *Engine) SetAotService(s *AotService) {
+	eng.aots = s
+}
+
 // RefreshApp --
 func RefreshApp(app *APP) {
-	aots.checkApp(app)
+	app.Eng.aots.checkApp(app)
 }
 
 // GetNative --
 func GetNative(app *APP) *Native {
-	return aots.getNative(app)
+	return app.Eng.aots.getNative(app)
 }
 
 // DeleteNative --
 func DeleteNative(app *APP) {
-	aots.deleteNative(app)
+	app.Eng.aots.deleteNative(app)
 }
 
-// StopAots --
+// StopAots stops the default service.
 func StopAots() {
-	aots.exit <- struct{}{}
+	if s := DefaultAotService(); s != nil {
+		s.Stop()
+	}
 }
 
 // ------------------------------------------------
 
-// ContractInfo --
+// An artifact is the native library compiled from one wasm code by one
+// version of the code generator. Contracts deployed with identical code share
+// the artifact, which is loaded once and unloaded when no contract using it
+// is left.
+
+// ContractInfo maps a contract to the artifact of its code.
 type ContractInfo struct {
-	Type string   `json:"t"`
+	Type     string `json:"t"`
+	Artifact string `json:"a"`
+	Compiler string `json:"cc"`
+}
+
+// legacyContractInfo holds the fields of the ContractInfo records written
+// before artifacts were shared, when each contract had its own library at
+// Path. Such records have no artifact and their contracts are compiled again.
+type legacyContractInfo struct {
 	Path string   `json:"p"`
 	MD5  [16]byte `json:"md5"`
 	Err  string   `json:"e"`
 }
 
+// ArtifactInfo --
+type ArtifactInfo struct {
+	Path     string `json:"p"`
+	CodeHash string `json:"code"` // sha256 of the wasm code
+	SHA256   string `json:"sha256"`
+	MAC      string `json:"mac,omitempty"`
+	Err      string `json:"e"`
+	Compiler string `json:"cc"`
+	Time     int64  `json:"ts,omitempty"` // unix time of the failure if Err
+}
+
+// artifact returns the name of the artifact of app, built from the sha256 of
+// its code, the code generator version and the compiler. Artifacts are shared
+// between contracts, so the code hash must be collision resistant.
+func (s *AotService) artifact(app *APP) string {
+	return hex.EncodeToString(app.codeHash[:]) + "-" + s.version
+}
+
+// addRef records that app uses artifact and reports whether it is new, the
+// caller holds s.lock.
+func (s *AotService) addRef(app *APP, artifact string) bool {
+	apps := s.refs[artifact]
+	if apps == nil {
+		apps = make(map[string]struct{})
+		s.refs[artifact] = apps
+	}
+	if _, ok := apps[app.Name]; ok {
+		return false
+	}
+	apps[app.Name] = struct{}{}
+	return true
+}
+
 func (s *AotService) checkApp(app *APP) {
-	if !enableAots {
+	s.request(app, false)
+}
+
+// request queues the artifact of app unless it is queued, loaded or
+// blacklisted. Urgent requests are compiled first and never dropped.
+func (s *AotService) request(app *APP, urgent bool) {
+	if s == nil || s.stopped() {
 		return
 	}
 
-	name := app.String()
+	name := s.artifact(app)
 	s.lock.Lock()
-	if _, ok := s.black[name]; !ok {
-		if _, ok := s.succ[name]; !ok {
-			s.succ[name] = nil
-			select {
-			case s.refresh <- app:
-			default:
-			}
+	defer s.lock.Unlock()
+	s.addRef(app, name)
+	if req, ok := s.queued[name]; ok {
+		req.hits++
+		req.urgent = req.urgent || urgent
+		heap.Fix(&s.queue, req.index)
+		return
+	}
+	if e := s.black[name]; e != nil && !s.retryDue(e, time.Now()) {
+		return
+	}
+	if _, ok := s.succ[name]; ok && (!urgent || s.ready[name] != nil) {
+		// loaded, compiling, or unloaded and not recompiled
+		return
+	}
+	if s.queue.Len() >= s.queueSize && !urgent {
+		s.stats.Dropped++
+		return
+	}
+
+	s.seq++
+	req := &aotRequest{app: app, name: name, hits: 1, seq: s.seq, urgent: urgent}
+	heap.Push(&s.queue, req)
+	s.queued[name] = req
+	if s.ready[name] == nil {
+		s.ready[name] = make(chan struct{})
+	}
+	s.cond.Signal()
+}
+
+// worker compiles or loads the queued contracts, most called first.
+func (s *AotService) worker() {
+	defer s.wg.Done()
+
+	for {
+		s.lock.Lock()
+		for s.queue.Len() == 0 && !s.stopped() {
+			s.cond.Wait()
+		}
+		if s.stopped() {
+			s.lock.Unlock()
+			return
 		}
+		req := heap.Pop(&s.queue).(*aotRequest)
+		delete(s.queued, req.name)
+
+		e := s.black[req.name]
+		black := e != nil && !s.retryDue(e, time.Now())
+		_, deleting := s.onDelete[req.name]
+		if black || (deleting && !req.urgent) || s.succ[req.name] != nil {
+			s.finish(req.name)
+			s.lock.Unlock()
+			continue
+		}
+		s.succ[req.name] = nil
+		s.stats.Compiling++
+		s.lock.Unlock()
+
+		req.app.Printf("[AotService] doCheck: app:%s, artifact:%s, hits:%d", req.app.Name, req.name, req.hits)
+		s.doCheck(req.app, req.name)
+
+		s.lock.Lock()
+		s.stats.Compiling--
+		s.stats.Done++
+		if native, ok := s.succ[req.name]; ok && native == nil {
+			// failed, retried once due
+			delete(s.succ, req.name)
+		}
+		s.finish(req.name)
+		s.lock.Unlock()
 	}
-	s.lock.Unlock()
 }
 
 func (s *AotService) getNative(app *APP) *Native {
-	if !enableAots {
+	if s == nil {
 		return nil
 	}
-	name := app.String()
+	name := s.artifact(app)
 
 	s.lock.Lock()
-	defer s.lock.Unlock()
+	native := s.succ[name].clone(app)
+	added := native != nil && s.addRef(app, name)
+	s.lock.Unlock()
 
-	native := s.succ[name]
-	return native.clone(app)
+	if added {
+		s.updateContractInfo(app, name)
+	}
+	return native
 }
 
+// deleteNative drops the reference of app to its artifact, the artifact is
+// removed once no contract uses it.
 func (s *AotService) deleteNative(app *APP) {
-	if !enableAots {
+	if s == nil {
 		return
 	}
 
-	name := app.String()
+	name := s.artifact(app)
 	s.lock.Lock()
+	defer s.lock.Unlock()
 
-	native := s.onDelete[name]
-	if native == nil {
-		native = s.succ[name]
-		if native != nil {
-			s.onDelete[name] = native
-			s.succ[name] = nil
-			native.remove()
-
-			app.Printf("[AotService] deleteNative begin: app:%s", name)
-			s.logger = app.logger
+	if apps := s.refs[name]; apps != nil {
+		delete(apps, app.Name)
+		if len(apps) != 0 {
+			app.Printf("[AotService] deleteNative: app:%s, artifact:%s still used by %d apps", app.Name, name, len(apps))
+			return
 		}
+		delete(s.refs, name)
+	}
+	s.unload(name, true)
+}
+
+// verifyFailed blacklists the artifact of app.
+func (s *AotService) verifyFailed(app *APP, report *VerifyReport) {
+	app.Printf("[AotService] native verify mismatch: %s", report)
+	s.lock.Lock()
+	s.stats.Mismatched++
+	s.lock.Unlock()
+
+	s.blacklist(app, aotErrMismatch)
+
+	if s.onMismatch != nil {
+		s.onMismatch(report)
+	}
+}
+
+// blacklist unloads the artifact of app and records reason in its
+// ArtifactInfo, so no contract with the same code runs it.
+func (s *AotService) blacklist(app *APP, reason string) {
+	if s == nil {
+		return
 	}
+	name := s.artifact(app)
+	info := s.getArtifactInfo(app, name)
+	if info == nil {
+		info = &ArtifactInfo{CodeHash: hex.EncodeToString(app.codeHash[:]), Compiler: s.compiler}
+	}
+	info.Err = reason
+	s.updateArtifactInfo(app, name, info)
 
+	s.lock.Lock()
+	s.unload(name, true)
 	s.lock.Unlock()
 }
 
 func (s *AotService) loop() {
-	// idle check timer
-	d1 := time.Duration(time.Minute * 5)
+	defer close(s.done)
+
+	// idle and limit check timer
+	d1 := s.evict.CheckInterval
 	t1 := time.NewTimer(d1)
 
 	// onDelete timer
-	d2 := time.Duration(time.Second * 10)
+	d2 := s.evict.DeleteDelay
 	t2 := time.NewTimer(d2)
 
 	for {
 		select {
-		case app := <-s.refresh:
-			name := app.String()
-			s.lock.Lock()
-			if _, ok := s.black[name]; ok {
-				s.lock.Unlock()
-				continue
-			}
-
-			if _, ok := s.onDelete[name]; ok {
-				s.lock.Unlock()
-				continue
-			}
-
-			n := s.succ[name]
-			s.lock.Unlock()
-
-			if n == nil {
-				app.Printf("[AotService] doCheck: app:%s", name)
-				s.doCheck(app)
-			}
-
 		case <-t1.C:
-			cnt := 0
-			now := time.Now()
-			target := time.Unix(now.Unix()-3600, 0) // one hour
-
-			s.lock.Lock()
-			for name, native := range s.succ {
-				if native == nil {
-					continue
-				}
-				if native.t.Before(target) {
-					s.succ[name] = nil
-					s.onDelete[name] = native
-					native.close()
-
-					cnt++
-					// fmt.Printf("[AotService] delete native: %s\n", name)
-				}
-				if cnt >= 3 {
-					break
-				}
-			}
-			s.lock.Unlock()
-
+			s.evictLoaded(time.Now())
 			t1.Reset(d1)
 
 		case <-t2.C:
@@ -202,8 +562,9 @@ func (s *AotService) loop() {
 			for name, native := range s.onDelete {
 				if native.count() == 0 {
 					delete(s.onDelete, name)
-					delete(s.succ, name)
-					delete(s.black, name)
+					if s.succ[name] == nil {
+						delete(s.succ, name)
+					}
 					// s.logger.Info("[AotService] deleteNative done", "app", name)
 				}
 			}
@@ -213,161 +574,180 @@ func (s *AotService) loop() {
 		case <-s.exit:
 			t1.Stop()
 			t2.Stop()
-			fmt.Printf("[AotService] Exit\n")
+			s.logger.Info("[AotService] Exit", "path", s.path)
 			return
 		}
 	}
 }
 
-func (s *AotService) doCheck(app *APP) error {
-	info := s.getContractInfo(app)
-	if info == nil {
-		return s.doWork(app)
-	}
-
-	name := app.String()
+func (s *AotService) doCheck(app *APP, artifact string) error {
 	// @Note: now we only support wasm
-	if info.Type != "wasm" {
-		app.Printf("[AotService] Not wasm contract, skip it: app:%s", name)
+	if cinfo := s.getContractInfo(app); cinfo != nil && cinfo.Type != "wasm" {
+		app.Printf("[AotService] Not wasm contract, skip it: app:%s", app.Name)
 		return nil
 	}
 
+	info := s.getArtifactInfo(app, artifact)
+	if info == nil {
+		return s.doWork(app, artifact)
+	}
+
 	if info.Err != "" {
-		app.Printf("[AotService] ContractInfo Has Err: app:%s, err:%s", name, info.Err)
 		s.lock.Lock()
-		s.black[name] = struct{}{}
+		e := s.black[artifact]
+		retry := e == nil || s.retryDue(e, time.Now())
 		s.lock.Unlock()
-		return fmt.Errorf(info.Err)
+		if !retry {
+			app.Printf("[AotService] ArtifactInfo Has Err: artifact:%s, err:%s", artifact, info.Err)
+			s.updateContractInfo(app, artifact)
+			return fmt.Errorf(info.Err)
+		}
+		app.Printf("[AotService] retry: artifact:%s, err:%s", artifact, info.Err)
+		if info.Path != "" {
+			os.Remove(info.Path)
+		}
+		return s.doWork(app, artifact)
 	}
 
-	stat, err := os.Stat(info.Path)
-	if err != nil {
-		app.Printf("[AotService] os.Stat %s fail: app:%s, err:%s", info.Path, name, err)
+	if info.CodeHash != hex.EncodeToString(app.codeHash[:]) {
+		app.Printf("[AotService] built from other code: artifact:%s, code:%s", artifact, info.CodeHash)
 		os.Remove(info.Path)
-		return s.doWork(app)
+		return s.doWork(app, artifact)
 	}
-	if stat.IsDir() {
-		app.Printf("[AotService] %s is dir, skip it: app:%s", info.Path, name)
-		if err = os.Remove(info.Path); err != nil {
-			return err
-		}
-		return s.doWork(app)
+
+	if info.Compiler != s.compiler {
+		app.Printf("[AotService] built by another compiler: artifact:%s, compiler:%s", artifact, info.Compiler)
+		os.Remove(info.Path)
+		return s.doWork(app, artifact)
 	}
 
-	data, err := ioutil.ReadFile(info.Path)
+	stat, err := os.Stat(info.Path)
 	if err != nil {
-		app.Printf("[AotService] ReadFile %s fail: %s", info.Path, err)
+		app.Printf("[AotService] os.Stat %s fail: artifact:%s, err:%s", info.Path, artifact, err)
+		os.Remove(info.Path)
+		return s.doWork(app, artifact)
+	}
+	if stat.IsDir() {
+		app.Printf("[AotService] %s is dir, skip it: artifact:%s", info.Path, artifact)
 		if err = os.Remove(info.Path); err != nil {
 			return err
 		}
-		return s.doWork(app)
+		return s.doWork(app, artifact)
 	}
 
-	sum := md5.Sum(data)
-	if !bytes.Equal(sum[:], info.MD5[:]) {
-		app.Printf("[AotService] MD5 Not match: wanted=%s, goted=%s",
-			hex.EncodeToString(info.MD5[:]), hex.EncodeToString(sum[:]))
+	if err = s.checkArtifact(artifact, info); err != nil {
+		s.logger.Error("[AotService] integrity check fail, rebuild", "artifact", artifact, "err", err)
 		if err = os.Remove(info.Path); err != nil {
 			return err
 		}
-		return s.doWork(app)
+		return s.doWork(app, artifact)
 	}
 
-	return s.doLoad(app, info)
+	return s.doLoad(app, artifact, info)
 }
 
-func (s *AotService) doWork(app *APP) error {
-	info, err := s.doCompile(app)
+func (s *AotService) doWork(app *APP, artifact string) error {
+	info, err := s.doCompile(app, artifact)
 	if err != nil {
-		app.Printf("[AotService] %s: app:%s, err:%s", info.Err, app.String(), err)
-		s.updateContractInfo(app, info)
+		app.Printf("[AotService] %s: artifact:%s, err:%s", info.Err, artifact, err)
+		s.updateArtifactInfo(app, artifact, info)
+		s.updateContractInfo(app, artifact)
 		return err
 	}
 
-	return s.doLoad(app, info)
+	return s.doLoad(app, artifact, info)
 }
 
-func (s *AotService) doLoad(app *APP, info *ContractInfo) error {
-	native, err := NewNative(app, info.Path)
+func (s *AotService) doLoad(app *APP, artifact string, info *ArtifactInfo) error {
+	native, err := s.loadArtifact(app, artifact, info)
 	if err != nil {
-		app.Printf("[AotService] NewNative fail: app:%s, err:%s", app.String(), err)
+		app.Printf("[AotService] NewNative fail: artifact:%s, err:%s", artifact, err)
 		info.Err = "NewNative Fail"
 	}
 
-	s.updateContractInfo(app, info)
+	s.updateArtifactInfo(app, artifact, info)
+	s.updateContractInfo(app, artifact)
 
 	if native != nil {
-		app.Printf("[AotService] NewNative ok: app:%s, md5:%s", app.String(), hex.EncodeToString(app.md5[:]))
+		app.Printf("[AotService] NewNative ok: app:%s, artifact:%s", app.Name, artifact)
 		s.lock.Lock()
-		s.succ[app.String()] = native
+		s.succ[artifact] = native
 		s.lock.Unlock()
 	}
 	return err
 }
 
-func (s *AotService) doCompile(app *APP) (*ContractInfo, error) {
-	info := ContractInfo{
-		Type: "wasm",
-		Err:  "",
-	}
-
+// generate returns the C code of app.
+func (s *AotService) generate(app *APP) ([]byte, error) {
 	// exec.SetCGenLogger(app.logger) // for debug
 	ctx := exec.NewCGenContext(app.VM, s.keepCSource)
 	code, err := ctx.Generate()
+	if err != nil {
+		return nil, err
+	}
+	if err = checkHostCalls(code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func (s *AotService) doCompile(app *APP, artifact string) (*ArtifactInfo, error) {
+	info := ArtifactInfo{
+		CodeHash: hex.EncodeToString(app.codeHash[:]),
+		Err:      "",
+		Compiler: s.compiler,
+	}
+
+	code, err := s.generate(app)
 	if err != nil {
 		info.Err = "Generate C Code Fail"
 		return &info, err
 	}
 
-	name := app.String()
-	file, err := ctx.Compile(code, s.path, name)
+	file, err := s.compile(code, artifact)
 	if err != nil {
 		info.Err = "Compile C Code Fail"
 		return &info, err
 	}
 
 	info.Path = file
-	info.MD5 = md5.Sum(code)
-	app.Printf("[AotService] doCompile ok: app:%s, so_md5:%s", name, hex.EncodeToString(info.MD5[:]))
+	if err = s.sign(artifact, &info); err != nil {
+		info.Err = "Sign Artifact Fail"
+		return &info, err
+	}
+	app.Printf("[AotService] doCompile ok: artifact:%s, so_sha256:%s", artifact, info.SHA256)
 	return &info, nil
 }
 
 var (
 	contractInfoPrefix = []byte("cfso:")
+	artifactInfoPrefix = []byte("cfar:")
 )
 
 const (
 	contractInfoPrefixLen = 5
+	artifactInfoPrefixLen = 5
 )
 
-func (s *AotService) updateContractInfo(app *APP, info *ContractInfo) {
-	name := app.String()
-
-	if info.Err != "" {
-		s.lock.Lock()
-		s.black[name] = struct{}{}
-		s.lock.Unlock()
-	}
-
-	data, err := json.Marshal(info)
+func (s *AotService) updateContractInfo(app *APP, artifact string) {
+	data, err := json.Marshal(&ContractInfo{Type: "wasm", Artifact: artifact, Compiler: s.compiler})
 	if err != nil {
 		app.Printf("[AotService] json.Marshal ContractInfo fail: %s", err)
 		return
 	}
 
-	key := make([]byte, contractInfoPrefixLen+len(name))
+	key := make([]byte, contractInfoPrefixLen+len(app.Name))
 	copy(key[:contractInfoPrefixLen], contractInfoPrefix)
-	copy(key[contractInfoPrefixLen:], []byte(name))
+	copy(key[contractInfoPrefixLen:], []byte(app.Name))
 
 	stateDB := app.Eng.State
 	stateDB.SetContractInfo(key, data)
 }
 
 func (s *AotService) getContractInfo(app *APP) *ContractInfo {
-	name := app.String()
-	key := make([]byte, contractInfoPrefixLen+len(name))
+	key := make([]byte, contractInfoPrefixLen+len(app.Name))
 	copy(key[:contractInfoPrefixLen], contractInfoPrefix)
-	copy(key[contractInfoPrefixLen:], []byte(name))
+	copy(key[contractInfoPrefixLen:], []byte(app.Name))
 
 	stateDB := app.Eng.State
 	data := stateDB.GetContractInfo(key)
@@ -375,35 +755,71 @@ func (s *AotService) getContractInfo(app *APP) *ContractInfo {
 		return nil
 	}
 
-	var info ContractInfo
+	var info struct {
+		ContractInfo
+		legacyContractInfo
+	}
 	if err := json.Unmarshal(data, &info); err != nil {
-		app.Printf("[AotService] json.Unmarshal ContractInfo fail: app:%s, err:%s", name, err)
+		app.Printf("[AotService] json.Unmarshal ContractInfo fail: app:%s, err:%s", app.Name, err)
 		return nil
 	}
-	return &info
+	if info.Artifact == "" && (info.Path != "" || info.Err != "") {
+		app.Printf("[AotService] legacy ContractInfo, recompile: app:%s, path:%s, err:%s", app.Name, info.Path, info.Err)
+		s.removeLegacy(info.Path)
+	}
+	return &info.ContractInfo
 }
 
-func init() {
-	path := os.Getenv(TCVM_AOTS_ROOT)
-	if path == "" {
-		path = "/tmp/aots"
+// removeLegacy removes the library of a legacy ContractInfo record if it is
+// in the root directory.
+func (s *AotService) removeLegacy(path string) {
+	if path == "" || s.path == "" || filepath.Dir(filepath.Clean(path)) != filepath.Clean(s.path) {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		s.logger.Error("[AotService] remove legacy library fail", "path", path, "err", err)
 	}
-	if err := os.MkdirAll(path, 0775); err != nil {
-		fmt.Printf("%s = %s, MkdirAll fail: %s\n", TCVM_AOTS_ROOT, path, err)
+}
+
+func (s *AotService) updateArtifactInfo(app *APP, artifact string, info *ArtifactInfo) {
+	s.lock.Lock()
+	if info.Err != "" {
+		info.Time = time.Now().Unix()
+		s.addBlack(app, artifact, info)
 	} else {
-		fmt.Printf("%s = %s, MkdirAll ok\n", TCVM_AOTS_ROOT, path)
+		s.removeBlack(artifact)
 	}
+	s.lock.Unlock()
 
-	keepSource := true
-	if os.Getenv(TCVM_AOTS_KEEP_CSOURCE) == "0" {
-		keepSource = false
+	data, err := json.Marshal(info)
+	if err != nil {
+		app.Printf("[AotService] json.Marshal ArtifactInfo fail: %s", err)
+		return
 	}
 
-	if os.Getenv(TCVM_AOTS_ENABLE) == "1" {
-		fmt.Printf("Enable AotService\n")
-		enableAots = true
+	key := make([]byte, artifactInfoPrefixLen+len(artifact))
+	copy(key[:artifactInfoPrefixLen], artifactInfoPrefix)
+	copy(key[artifactInfoPrefixLen:], []byte(artifact))
+
+	stateDB := app.Eng.State
+	stateDB.SetContractInfo(key, data)
+}
+
+func (s *AotService) getArtifactInfo(app *APP, artifact string) *ArtifactInfo {
+	key := make([]byte, artifactInfoPrefixLen+len(artifact))
+	copy(key[:artifactInfoPrefixLen], artifactInfoPrefix)
+	copy(key[artifactInfoPrefixLen:], []byte(artifact))
+
+	stateDB := app.Eng.State
+	data := stateDB.GetContractInfo(key)
+	if len(data) == 0 {
+		return nil
 	}
 
-	aots = NewAotService(path, keepSource)
-	go aots.loop()
+	var info ArtifactInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		app.Printf("[AotService] json.Unmarshal ArtifactInfo fail: artifact:%s, err:%s", artifact, err)
+		return nil
+	}
+	return &info
 }
@@ -0,0 +1,283 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Artifacts failing to build or load are retried with exponential backoff,
+// and at once when the compiler changes. Artifacts whose code ran wrong,
+// differing from the interpreter or crashing, are retried only when the
+// compiler changes or an operator clears them. The blacklist is kept in the
+// root directory so that it survives restarts; artifacts failed in StateDB
+// but not in the blacklist are rebuilt.
+
+const (
+	aotErrMismatch = "Native Verify Mismatch"
+	aotErrCrash    = "Native Crash"
+
+	aotBlacklistFile = "blacklist.json"
+)
+
+// AotBlackEntry is a blacklisted artifact.
+type AotBlackEntry struct {
+	Artifact  string    `json:"artifact"`
+	Apps      []string  `json:"apps,omitempty"` // contracts using the artifact
+	Reason    string    `json:"reason"`
+	Compiler  string    `json:"compiler"`
+	Time      time.Time `json:"time"`       // last failure
+	Retries   int       `json:"retries"`    // failures with the compiler
+	NextRetry time.Time `json:"next_retry"` // zero if retried only when cleared
+
+	app *APP // contract the artifact failed for, if failed since start
+}
+
+// retryDue reports whether the artifact of e is built again, the caller holds
+// s.lock.
+func (s *AotService) retryDue(e *AotBlackEntry, now time.Time) bool {
+	if e.Compiler != s.compiler {
+		return true
+	}
+	return !e.NextRetry.IsZero() && !now.Before(e.NextRetry)
+}
+
+// retryDelay returns the backoff after retries failures.
+func (s *AotService) retryDelay(retries int) time.Duration {
+	d := s.retryMin
+	for i := 1; i < retries && d < s.retryMax; i++ {
+		d *= 2
+	}
+	if d > s.retryMax {
+		d = s.retryMax
+	}
+	return d
+}
+
+// addBlack blacklists artifact after a failure of app, the caller holds
+// s.lock.
+func (s *AotService) addBlack(app *APP, artifact string, info *ArtifactInfo) {
+	now := time.Now()
+	e := &AotBlackEntry{
+		Artifact: artifact,
+		Reason:   info.Err,
+		Compiler: info.Compiler,
+		Time:     now,
+		Retries:  1,
+		app:      app,
+	}
+	if prev := s.black[artifact]; prev != nil && prev.Compiler == e.Compiler {
+		e.Retries = prev.Retries + 1
+	}
+	if e.Reason != aotErrMismatch && e.Reason != aotErrCrash {
+		e.NextRetry = now.Add(s.retryDelay(e.Retries))
+	}
+	s.black[artifact] = e
+	s.saveBlacklist()
+}
+
+// removeBlack drops artifact from the blacklist, the caller holds s.lock.
+func (s *AotService) removeBlack(artifact string) {
+	if _, ok := s.black[artifact]; ok {
+		delete(s.black, artifact)
+		s.saveBlacklist()
+	}
+}
+
+// Blacklist lists the blacklisted artifacts, sorted by name.
+func (s *AotService) Blacklist() []AotBlackEntry {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	list := make([]AotBlackEntry, 0, len(s.black))
+	for name, e := range s.black {
+		entry := *e
+		entry.Apps = nil
+		for app := range s.refs[name] {
+			entry.Apps = append(entry.Apps, app)
+		}
+		sort.Strings(entry.Apps)
+		list = append(list, entry)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Artifact < list[j].Artifact })
+	return list
+}
+
+// ClearBlacklist removes the artifact named name, or used by the contract
+// name, from the blacklist, or all artifacts if name is empty. The artifacts
+// are built again when next run. It returns the number of artifacts removed.
+func (s *AotService) ClearBlacklist(name string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	n := 0
+	for artifact := range s.black {
+		if name == "" || s.matches(artifact, name) {
+			delete(s.black, artifact)
+			n++
+		}
+	}
+	if n != 0 {
+		s.saveBlacklist()
+	}
+	s.logger.Info("[AotService] clear blacklist", "name", name, "cleared", n)
+	return n
+}
+
+// Recompile removes the library of the artifact named name, or used by the
+// contract name, and clears it from the blacklist. The artifact is compiled
+// at once if a contract using it ran since the start of the service,
+// otherwise when next run. It returns ErrNoNative if no such artifact is
+// known.
+func (s *AotService) Recompile(name string) error {
+	s.lock.Lock()
+	artifact, app := s.lookup(name)
+	if artifact == "" {
+		s.lock.Unlock()
+		return ErrNoNative
+	}
+	s.unload(artifact, false)
+	s.removeBlack(artifact)
+	s.lock.Unlock()
+
+	path := filepath.Join(s.path, artifact+".so")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	s.logger.Info("[AotService] recompile", "artifact", artifact, "app", app != nil)
+	if app != nil {
+		s.request(app, true)
+	}
+	return nil
+}
+
+// matches reports whether name is artifact or a contract using it, the
+// caller holds s.lock.
+func (s *AotService) matches(artifact, name string) bool {
+	if artifact == name {
+		return true
+	}
+	_, ok := s.refs[artifact][name]
+	return ok
+}
+
+// lookup returns the artifact named name or used by the contract name, and a
+// contract to compile it for if known. The caller holds s.lock.
+func (s *AotService) lookup(name string) (string, *APP) {
+	var found string
+	for artifact := range s.refs {
+		if s.matches(artifact, name) {
+			found = artifact
+		}
+	}
+	for artifact := range s.black {
+		if s.matches(artifact, name) {
+			found = artifact
+		}
+	}
+	if found == "" {
+		if _, err := os.Stat(filepath.Join(s.path, name+".so")); err != nil {
+			return "", nil
+		}
+		return name, nil
+	}
+	if native := s.succ[found]; native != nil {
+		return found, native.app
+	}
+	if e := s.black[found]; e != nil && e.app != nil {
+		return found, e.app
+	}
+	return found, nil
+}
+
+// ReadAotBlacklist returns the blacklist of the AOT root directory root,
+// sorted by artifact, without starting an AotService, for the tools run while
+// the node is stopped.
+func ReadAotBlacklist(root string) ([]AotBlackEntry, error) {
+	list, err := readBlacklist(root)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]AotBlackEntry, len(list))
+	for i, e := range list {
+		entries[i] = *e
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Artifact < entries[j].Artifact })
+	return entries, nil
+}
+
+// WriteAotBlacklist replaces the blacklist of the AOT root directory root by
+// list. The node reads it when it starts.
+func WriteAotBlacklist(root string, list []AotBlackEntry) error {
+	entries := make([]*AotBlackEntry, len(list))
+	for i := range list {
+		entries[i] = &list[i]
+	}
+	return writeBlacklist(root, entries)
+}
+
+// loadBlacklist reads the blacklist of the root directory.
+func (s *AotService) loadBlacklist() error {
+	list, err := readBlacklist(s.path)
+	if err != nil {
+		return err
+	}
+	for _, e := range list {
+		e.Apps = nil // s.refs is rebuilt as contracts run
+		s.black[e.Artifact] = e
+	}
+	return nil
+}
+
+// saveBlacklist writes the blacklist to the root directory, the caller holds
+// s.lock.
+func (s *AotService) saveBlacklist() {
+	if s.path == "" {
+		return
+	}
+	list := make([]*AotBlackEntry, 0, len(s.black))
+	for _, e := range s.black {
+		list = append(list, e)
+	}
+	if err := writeBlacklist(s.path, list); err != nil {
+		s.logger.Error("[AotService] write blacklist fail", "err", err)
+	}
+}
+
+// readBlacklist reads the blacklist of the root directory, which is empty if
+// it has no blacklist file.
+func readBlacklist(root string) ([]*AotBlackEntry, error) {
+	data, err := ioutil.ReadFile(filepath.Join(root, aotBlacklistFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []*AotBlackEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("aot: %s: %v", aotBlacklistFile, err)
+	}
+	return list, nil
+}
+
+// writeBlacklist writes list, sorted by artifact, to the root directory
+// through a temporary file.
+func writeBlacklist(root string, list []*AotBlackEntry) error {
+	sort.Slice(list, func(i, j int) bool { return list[i].Artifact < list[j].Artifact })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(root, aotBlacklistFile)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
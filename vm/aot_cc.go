@@ -0,0 +1,113 @@
+package vm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AotToolchain selects the C compiler building the artifacts.
+type AotToolchain struct {
+	CC       string   // gcc, clang or tcc, or a path to one of them
+	OptLevel string   // passed as -O, ignored by tcc
+	Flags    []string // extra compiler flags
+}
+
+// DefaultAotToolchain --
+func DefaultAotToolchain() AotToolchain {
+	return AotToolchain{
+		CC:       "gcc",
+		OptLevel: "2",
+	}
+}
+
+func (tc AotToolchain) isTCC() bool {
+	return strings.Contains(filepath.Base(tc.CC), "tcc")
+}
+
+func (tc AotToolchain) args(in, out string) []string {
+	args := []string{"-shared"}
+	if !tc.isTCC() {
+		args = append(args, "-fPIC")
+		if tc.OptLevel != "" {
+			args = append(args, "-O"+tc.OptLevel)
+		}
+	}
+	args = append(args, tc.Flags...)
+	return append(args, "-o", out, in)
+}
+
+func (tc AotToolchain) run(in, out string) error {
+	output, err := exec.Command(tc.CC, tc.args(in, out)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %v: %s", tc.CC, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// probe checks that the compiler builds shared libraries in dir and returns
+// its identity, the first line of its version and the flags.
+func (tc AotToolchain) probe(dir string) (string, error) {
+	version := "--version"
+	if tc.isTCC() {
+		version = "-v"
+	}
+	output, err := exec.Command(tc.CC, version).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("aot: probe %s: %v", tc.CC, err)
+	}
+	ident := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	if ident == "" {
+		return "", fmt.Errorf("aot: probe %s: no version", tc.CC)
+	}
+
+	in := filepath.Join(dir, ".probe.c")
+	out := filepath.Join(dir, ".probe.so")
+	defer os.Remove(in)
+	defer os.Remove(out)
+	if err := ioutil.WriteFile(in, []byte("int tcvm_probe(void) { return 0; }\n"), 0600); err != nil {
+		return "", err
+	}
+	if err := tc.run(in, out); err != nil {
+		return "", fmt.Errorf("aot: probe: %v", err)
+	}
+
+	args := tc.args("", "")
+	return ident + " " + strings.Join(args[:len(args)-3], " "), nil
+}
+
+// setCompiler sets the compiler identity, artifacts are named after it so
+// that artifacts of other toolchains are rebuilt.
+func (s *AotService) setCompiler(ident string) {
+	sum := sha256.Sum256([]byte(ident))
+	s.compiler = ident
+	s.version = aotCodegenVersion + "-" + hex.EncodeToString(sum[:4])
+}
+
+// Compiler returns the identity of the probed compiler.
+func (s *AotService) Compiler() string {
+	return s.compiler
+}
+
+func (s *AotService) compile(code []byte, name string) (string, error) {
+	in := filepath.Join(s.path, name+".c")
+	out := filepath.Join(s.path, name+".so")
+	if err := ioutil.WriteFile(in, code, 0600); err != nil {
+		return "", err
+	}
+	if !s.keepCSource {
+		defer os.Remove(in)
+	}
+	// a previous library is read only
+	os.Remove(out)
+
+	if err := s.toolchain.run(in, out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,250 @@
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EvictPolicy orders loaded artifacts for eviction, it reports whether a is
+// evicted before b.
+type EvictPolicy func(a, b *AotEntry) bool
+
+// EvictLRU evicts the least recently used artifacts first.
+func EvictLRU(a, b *AotEntry) bool {
+	return a.LastUsed.Before(b.LastUsed)
+}
+
+// EvictLFU evicts the least frequently used artifacts first, then the least
+// recently used.
+func EvictLFU(a, b *AotEntry) bool {
+	if a.Uses != b.Uses {
+		return a.Uses < b.Uses
+	}
+	return EvictLRU(a, b)
+}
+
+// AotEvictConfig limits the artifacts kept by an AotService. Limits and
+// IdleTimeout of 0 are unlimited, intervals of 0 are the defaults.
+type AotEvictConfig struct {
+	Policy        EvictPolicy   // eviction order when over a limit, EvictLRU if nil
+	MaxLoaded     int           // loaded libraries
+	MaxDiskSize   int64         // bytes of libraries in the root directory
+	IdleTimeout   time.Duration // unload libraries unused for longer
+	CheckInterval time.Duration // interval of the idle and limit checks
+	DeleteDelay   time.Duration // interval of freeing unloaded libraries no longer running
+	Pinned        []string      // contracts whose libraries are never evicted
+}
+
+// DefaultAotEvictConfig --
+func DefaultAotEvictConfig() AotEvictConfig {
+	return AotEvictConfig{
+		Policy:        EvictLRU,
+		IdleTimeout:   time.Hour,
+		CheckInterval: 5 * time.Minute,
+		DeleteDelay:   10 * time.Second,
+	}
+}
+
+// AotEntry describes a loaded artifact.
+type AotEntry struct {
+	Artifact string
+	Path     string
+	Size     int64
+	Apps     []string  // contracts using the artifact
+	Refs     uint64    // references to the library, one per running contract plus the service
+	Uses     uint64    // contract instances created with the library
+	LastUsed time.Time // last contract instance created
+	Pinned   bool
+}
+
+func (s *AotService) setEvictConfig(cfg AotEvictConfig) {
+	def := DefaultAotEvictConfig()
+	if cfg.Policy == nil {
+		cfg.Policy = def.Policy
+	}
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = def.CheckInterval
+	}
+	if cfg.DeleteDelay <= 0 {
+		cfg.DeleteDelay = def.DeleteDelay
+	}
+	s.evict = cfg
+	for _, name := range cfg.Pinned {
+		s.pinned[name] = struct{}{}
+	}
+}
+
+// Pin keeps the artifact of contract name loaded once loaded.
+func (s *AotService) Pin(name string) {
+	s.lock.Lock()
+	s.pinned[name] = struct{}{}
+	s.lock.Unlock()
+}
+
+// Unpin --
+func (s *AotService) Unpin(name string) {
+	s.lock.Lock()
+	delete(s.pinned, name)
+	s.lock.Unlock()
+}
+
+// Loaded lists the loaded artifacts, sorted by name.
+func (s *AotService) Loaded() []AotEntry {
+	s.lock.Lock()
+	entries := s.loaded()
+	s.lock.Unlock()
+
+	list := make([]AotEntry, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, *e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Artifact < list[j].Artifact })
+	return list
+}
+
+// Unload unloads the artifact named name, or used by the contract name. The
+// library is freed once the contracts running it return. It reports whether
+// the artifact was loaded.
+func (s *AotService) Unload(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.succ[name] != nil {
+		s.unload(name, false)
+		return true
+	}
+	for artifact, apps := range s.refs {
+		if _, ok := apps[name]; ok && s.succ[artifact] != nil {
+			s.unload(artifact, false)
+			return true
+		}
+	}
+	return false
+}
+
+// loaded returns the loaded artifacts, the caller holds s.lock.
+func (s *AotService) loaded() []*AotEntry {
+	var entries []*AotEntry
+	for name, native := range s.succ {
+		if native == nil {
+			continue
+		}
+		e := &AotEntry{
+			Artifact: name,
+			Path:     native.dl.file,
+			Refs:     native.count(),
+			Uses:     native.uses,
+			LastUsed: native.t,
+		}
+		if stat, err := os.Stat(e.Path); err == nil {
+			e.Size = stat.Size()
+		}
+		for app := range s.refs[name] {
+			e.Apps = append(e.Apps, app)
+			if _, ok := s.pinned[app]; ok {
+				e.Pinned = true
+			}
+		}
+		sort.Strings(e.Apps)
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// unload moves the library of artifact to onDelete, removing its file if del,
+// the caller holds s.lock.
+func (s *AotService) unload(name string, del bool) {
+	native := s.succ[name]
+	if native == nil {
+		return
+	}
+	s.succ[name] = nil
+	s.onDelete[name] = native
+	if del {
+		native.remove()
+	} else {
+		native.close()
+	}
+	s.logger.Info("[AotService] unload", "artifact", name, "delete", del)
+}
+
+// evictLoaded unloads idle artifacts and the artifacts over the limits, in
+// the order of the policy.
+func (s *AotService) evictLoaded(now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries := s.loaded()
+	loaded := len(entries)
+	candidates := entries[:0]
+	for _, e := range entries {
+		if e.Pinned {
+			continue
+		}
+		if s.evict.IdleTimeout > 0 && now.Sub(e.LastUsed) > s.evict.IdleTimeout {
+			s.unload(e.Artifact, false)
+			loaded--
+			continue
+		}
+		candidates = append(candidates, e)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return s.evict.Policy(candidates[i], candidates[j]) })
+
+	for s.evict.MaxLoaded > 0 && loaded > s.evict.MaxLoaded && len(candidates) > 0 {
+		s.unload(candidates[0].Artifact, false)
+		candidates = candidates[1:]
+		loaded--
+	}
+
+	if s.evict.MaxDiskSize > 0 {
+		s.trimDisk(candidates)
+	}
+}
+
+// trimDisk removes libraries until the root directory is within the disk
+// limit, first those not loaded, oldest first, then the loaded candidates.
+// The caller holds s.lock.
+func (s *AotService) trimDisk(candidates []*AotEntry) {
+	files, err := ioutil.ReadDir(s.path)
+	if err != nil {
+		s.logger.Info("[AotService] ReadDir fail", "path", s.path, "err", err)
+		return
+	}
+
+	var total int64
+	var unloaded []os.FileInfo
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".so") {
+			continue
+		}
+		total += f.Size()
+		name := strings.TrimSuffix(f.Name(), ".so")
+		_, known := s.succ[name]
+		_, deleting := s.onDelete[name]
+		if !known && !deleting {
+			unloaded = append(unloaded, f)
+		}
+	}
+	sort.Slice(unloaded, func(i, j int) bool { return unloaded[i].ModTime().Before(unloaded[j].ModTime()) })
+
+	for _, f := range unloaded {
+		if total <= s.evict.MaxDiskSize {
+			return
+		}
+		if err := os.Remove(filepath.Join(s.path, f.Name())); err != nil {
+			continue
+		}
+		total -= f.Size()
+	}
+	for _, e := range candidates {
+		if total <= s.evict.MaxDiskSize {
+			return
+		}
+		s.unload(e.Artifact, true)
+		total -= e.Size
+	}
+}
@@ -0,0 +1,28 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// The C code generator of the wagon fork calls host functions through
+// GoFuncIndex with their index in the env_func_names table, and exports the
+// table as thunderchain_env_funcs, which Native resolves against the EnvTable
+// once at load time, checking it against the imports of the module.
+// checkHostCalls fails the build of code generated otherwise, e.g. by an
+// upstream wagon calling GoFunc by name, which Native does not export.
+
+var (
+	envFuncsExport = []byte("int32_t thunderchain_env_funcs(const char ***names)")
+	goFuncCall     = []byte("GoFunc(vm, ")
+)
+
+func checkHostCalls(code []byte) error {
+	if !bytes.Contains(code, envFuncsExport) {
+		return fmt.Errorf("aot: generated code exports no thunderchain_env_funcs")
+	}
+	if bytes.Contains(code, goFuncCall) {
+		return fmt.Errorf("aot: generated code calls host functions by name")
+	}
+	return nil
+}
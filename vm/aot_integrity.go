@@ -0,0 +1,166 @@
+package vm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"syscall"
+)
+
+// Artifacts are native code run in consensus, so a library is loaded only if
+// it is a regular file owned by the node user, not writable by others, in a
+// root directory of the same kind, and its SHA-256, and HMAC when the node
+// has a key, match the ArtifactInfo written when it was compiled. The file
+// checked is the file loaded, through its descriptor.
+
+// checkOwner checks that fi is owned by the node user and not writable by
+// group or others.
+func checkOwner(path string, fi os.FileInfo) error {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("aot: %s is owned by uid %d", path, st.Uid)
+	}
+	if fi.Mode().Perm()&0022 != 0 {
+		return fmt.Errorf("aot: %s is writable by group or others (%s)", path, fi.Mode().Perm())
+	}
+	return nil
+}
+
+// checkRoot checks the root directory, tightening its permissions if it is
+// owned by the node user.
+func checkRoot(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("aot: %s is not a directory", path)
+	}
+	if err := checkOwner(path, fi); err != nil {
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+			return err
+		}
+		return os.Chmod(path, 0700)
+	}
+	return nil
+}
+
+// readHMACKey reads the node key, which must be readable by the node user only.
+func readHMACKey(path string) ([]byte, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwner(path, fi); err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0044 != 0 {
+		return nil, fmt.Errorf("aot: key %s is readable by group or others (%s)", path, fi.Mode().Perm())
+	}
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("aot: key %s is empty", path)
+	}
+	return key, nil
+}
+
+// digest returns the SHA-256 of the library data of artifact, and its HMAC
+// if the service has a key.
+func (s *AotService) digest(artifact string, data []byte) (sum, mac string) {
+	h := sha256.Sum256(data)
+	sum = hex.EncodeToString(h[:])
+	if len(s.hmacKey) != 0 {
+		m := hmac.New(sha256.New, s.hmacKey)
+		m.Write([]byte(artifact))
+		m.Write(h[:])
+		mac = hex.EncodeToString(m.Sum(nil))
+	}
+	return sum, mac
+}
+
+// sign sets the digests of the library at info.Path and restricts its
+// permissions.
+func (s *AotService) sign(artifact string, info *ArtifactInfo) error {
+	if err := os.Chmod(info.Path, 0400); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(info.Path)
+	if err != nil {
+		return err
+	}
+	info.SHA256, info.MAC = s.digest(artifact, data)
+	return nil
+}
+
+// openArtifact opens the library of info and checks it, the library is to be
+// loaded through the returned file.
+func (s *AotService) openArtifact(artifact string, info *ArtifactInfo) (*os.File, error) {
+	f, err := os.OpenFile(info.Path, os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkFile(artifact, info, f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *AotService) checkFile(artifact string, info *ArtifactInfo, f *os.File) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("aot: %s is not a regular file", info.Path)
+	}
+	if err := checkOwner(info.Path, fi); err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	sum, mac := s.digest(artifact, data)
+	if sum != info.SHA256 {
+		return fmt.Errorf("aot: %s SHA-256 %s, want %s", info.Path, sum, info.SHA256)
+	}
+	if !hmac.Equal([]byte(mac), []byte(info.MAC)) {
+		return fmt.Errorf("aot: %s HMAC mismatch", info.Path)
+	}
+	return nil
+}
+
+// checkArtifact checks the library of info without loading it.
+func (s *AotService) checkArtifact(artifact string, info *ArtifactInfo) error {
+	f, err := s.openArtifact(artifact, info)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// loadArtifact checks the library of info and loads the checked file.
+func (s *AotService) loadArtifact(app *APP, artifact string, info *ArtifactInfo) (*Native, error) {
+	f, err := s.openArtifact(artifact, info)
+	if err != nil {
+		return nil, err
+	}
+	if s.sandbox != nil {
+		// the helper loads f
+		return newSandboxNative(app, info.Path, f, s.sandbox), nil
+	}
+	defer f.Close()
+
+	open := fmt.Sprintf("/proc/self/fd/%d", f.Fd())
+	if _, err := os.Stat(open); err != nil {
+		open = info.Path
+	}
+	return newNative(app, info.Path, open)
+}
@@ -0,0 +1,49 @@
+package vm
+
+// aotRequest is a contract waiting to be compiled. Precompiled contracts are
+// compiled first, then contracts run more often while waiting.
+type aotRequest struct {
+	app    *APP
+	name   string
+	hits   uint64
+	seq    uint64
+	urgent bool
+	index  int
+}
+
+// aotQueue implements heap.Interface.
+type aotQueue []*aotRequest
+
+func (q aotQueue) Len() int { return len(q) }
+
+func (q aotQueue) Less(i, j int) bool {
+	if q[i].urgent != q[j].urgent {
+		return q[i].urgent
+	}
+	if q[i].hits != q[j].hits {
+		return q[i].hits > q[j].hits
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q aotQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *aotQueue) Push(x interface{}) {
+	req := x.(*aotRequest)
+	req.index = len(*q)
+	*q = append(*q, req)
+}
+
+func (q *aotQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	req := old[n-1]
+	old[n-1] = nil
+	req.index = -1
+	*q = old[:n-1]
+	return req
+}
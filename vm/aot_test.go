@@ -0,0 +1,552 @@
+package vm
+
+import (
+	"container/heap"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/state"
+	"github.com/xunleichain/tc-wasm/mock/types"
+)
+
+func TestAotServiceLifecycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var services []*AotService
+	for _, name := range []string{"a", "b"} {
+		cfg := DefaultAotConfig()
+		cfg.Root = filepath.Join(dir, name)
+		s, err := StartAotService(cfg)
+		if err != nil {
+			t.Fatalf("start service: %v", err)
+		}
+		if _, err := os.Stat(cfg.Root); err != nil {
+			t.Fatalf("root not created: %v", err)
+		}
+		services = append(services, s)
+	}
+
+	services[0].Stop()
+	services[0].Stop()
+	if !services[0].stopped() || services[1].stopped() {
+		t.Fatalf("services are not independent")
+	}
+	services[1].Stop()
+
+	var none *AotService
+	if none.getNative(nil) != nil {
+		t.Fatalf("nil service returned native code")
+	}
+}
+
+func TestAotQueuePriority(t *testing.T) {
+	// not started, so the requests stay queued
+	s := NewAotService("", true)
+	s.queueSize = 3
+
+	apps := []*APP{
+		{Name: "a", codeHash: [32]byte{1}},
+		{Name: "b", codeHash: [32]byte{2}},
+		{Name: "c", codeHash: [32]byte{3}},
+		{Name: "d", codeHash: [32]byte{4}},
+	}
+	for _, app := range apps {
+		s.checkApp(app)
+	}
+	s.checkApp(apps[2])
+	s.checkApp(apps[2])
+	s.checkApp(apps[1])
+
+	st := s.Stats()
+	if st.Queued != 3 || st.Dropped != 1 {
+		t.Fatalf("unexpected stats: %+v", st)
+	}
+
+	var order []string
+	for s.queue.Len() > 0 {
+		order = append(order, heap.Pop(&s.queue).(*aotRequest).app.Name)
+	}
+	if strings.Join(order, ",") != "c,b,a" {
+		t.Fatalf("unexpected compile order: %v", order)
+	}
+}
+
+func TestAotSharedArtifact(t *testing.T) {
+	s := NewAotService("", true)
+
+	a := &APP{Name: "a", codeHash: [32]byte{1}, logger: log.Test()}
+	b := &APP{Name: "b", codeHash: [32]byte{1}, logger: log.Test()}
+	c := &APP{Name: "c", codeHash: [32]byte{2}, logger: log.Test()}
+	for _, app := range []*APP{a, b, c} {
+		s.checkApp(app)
+	}
+	if s.artifact(a) != s.artifact(b) || s.artifact(a) == s.artifact(c) {
+		t.Fatalf("artifacts not keyed by code: %s %s %s", s.artifact(a), s.artifact(b), s.artifact(c))
+	}
+	if st := s.Stats(); st.Queued != 2 {
+		t.Fatalf("identical code queued twice: %+v", st)
+	}
+
+	name := s.artifact(a)
+	s.deleteNative(a)
+	if _, ok := s.refs[name]["b"]; !ok || len(s.refs[name]) != 1 {
+		t.Fatalf("unexpected refs after delete: %v", s.refs[name])
+	}
+	s.deleteNative(b)
+	if _, ok := s.refs[name]; ok {
+		t.Fatalf("artifact still referenced: %v", s.refs[name])
+	}
+}
+
+func TestAotLegacyContractInfo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, _ := state.New()
+	s := NewAotService(dir, true)
+	app := &APP{Name: "a", Eng: &Engine{State: db}, logger: log.Test()}
+
+	// written before artifacts were shared, with the library of the contract
+	lib := filepath.Join(dir, "a.so")
+	if err := ioutil.WriteFile(lib, []byte("library"), 0400); err != nil {
+		t.Fatal(err)
+	}
+	old := fmt.Sprintf(`{"t":"wasm","p":%q,"md5":[%s],"e":""}`, lib, strings.Repeat("1,", 15)+"1")
+	db.SetContractInfo(append([]byte("cfso:"), app.Name...), []byte(old))
+
+	info := s.getContractInfo(app)
+	if info == nil || info.Type != "wasm" || info.Artifact != "" {
+		t.Fatalf("legacy record not decoded: %+v", info)
+	}
+	if _, err := os.Stat(lib); !os.IsNotExist(err) {
+		t.Fatalf("legacy library kept: %v", err)
+	}
+
+	s.setCompiler("cc 1")
+	s.updateContractInfo(app, "x")
+	if info := s.getContractInfo(app); info == nil || info.Artifact != "x" || info.Compiler != "cc 1" {
+		t.Fatalf("record not rewritten: %+v", info)
+	}
+}
+
+func TestAotArtifactCodeHash(t *testing.T) {
+	s := NewAotService("", true)
+	a := &APP{Name: "a", codeHash: [32]byte{1}, logger: log.Test()}
+	if name := s.artifact(a); !strings.HasPrefix(name, hex.EncodeToString(a.codeHash[:])+"-") {
+		t.Fatalf("artifact not named by the sha256 of the code: %s", name)
+	}
+
+	// a library loaded for other code is never handed out
+	native := &Native{codeHash: [32]byte{2}}
+	if native.clone(a) != nil {
+		t.Fatal("cloned a library built from other code")
+	}
+}
+
+func TestAotEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	load := func(policy EvictPolicy) *AotService {
+		s := NewAotService(dir, true)
+		s.setEvictConfig(AotEvictConfig{Policy: policy, MaxLoaded: 2, Pinned: []string{"c"}})
+		for i, name := range []string{"a", "b", "c"} {
+			file := filepath.Join(dir, name+".so")
+			if err := ioutil.WriteFile(file, []byte(name), 0644); err != nil {
+				t.Fatal(err)
+			}
+			s.succ[name] = &Native{
+				dl:   newDynamicLib(file, nil, log.Test()),
+				t:    now.Add(time.Duration(i-3) * time.Minute),
+				uses: uint64(10 - 4*i),
+			}
+			s.refs[name] = map[string]struct{}{name: {}}
+		}
+		return s
+	}
+	loaded := func(s *AotService) string {
+		var names []string
+		for _, e := range s.Loaded() {
+			names = append(names, e.Artifact)
+		}
+		return strings.Join(names, ",")
+	}
+
+	// a: oldest, most used; b: newer, less used; c: pinned
+	s := load(EvictLRU)
+	s.evictLoaded(now)
+	if got := loaded(s); got != "b,c" {
+		t.Fatalf("LRU kept %s", got)
+	}
+	s = load(EvictLFU)
+	s.evictLoaded(now)
+	if got := loaded(s); got != "a,c" {
+		t.Fatalf("LFU kept %s", got)
+	}
+
+	s.evict.IdleTimeout = time.Minute
+	s.evictLoaded(now)
+	if got := loaded(s); got != "c" {
+		t.Fatalf("idle check kept %s", got)
+	}
+	if e := s.Loaded()[0]; !e.Pinned || e.Refs != 1 || e.Size != 1 {
+		t.Fatalf("unexpected entry: %+v", e)
+	}
+	if !s.Unload("c") || s.Unload("c") || loaded(s) != "" {
+		t.Fatalf("unload of pinned contract failed")
+	}
+}
+
+func TestAotToolchainProbe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultAotConfig()
+	cfg.Root = dir
+	cfg.Toolchain.CC = "no-such-cc"
+	if _, err := StartAotService(cfg); err == nil {
+		t.Fatalf("missing compiler not detected")
+	}
+
+	cfg.Toolchain = DefaultAotToolchain()
+	s, err := StartAotService(cfg)
+	if err != nil {
+		t.Skipf("no C compiler: %v", err)
+	}
+	defer s.Stop()
+	if s.Compiler() == "" || !strings.HasPrefix(s.version, aotCodegenVersion+"-") {
+		t.Fatalf("unexpected compiler %q, version %q", s.Compiler(), s.version)
+	}
+
+	cfg.Toolchain.OptLevel = "0"
+	s0, err := StartAotService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s0.Stop()
+	if s0.version == s.version {
+		t.Fatalf("artifacts of different flags share version %q", s.version)
+	}
+}
+
+func TestAotArtifactIntegrity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Chmod(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRoot(dir); err != nil {
+		t.Fatalf("check root: %v", err)
+	}
+	if fi, _ := os.Stat(dir); fi.Mode().Perm() != 0700 {
+		t.Fatalf("root permissions not restricted: %s", fi.Mode().Perm())
+	}
+
+	s := NewAotService(dir, true)
+	s.hmacKey = []byte("node key")
+	info := &ArtifactInfo{Path: filepath.Join(dir, "x.so")}
+	if err := ioutil.WriteFile(info.Path, []byte("library"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.sign("x", info); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := s.checkArtifact("x", info); err != nil {
+		t.Fatalf("check signed artifact: %v", err)
+	}
+	if err := s.checkArtifact("y", info); err == nil {
+		t.Fatalf("artifact accepted under another name")
+	}
+
+	other := NewAotService(dir, true)
+	other.hmacKey = []byte("other key")
+	if err := other.checkArtifact("x", info); err == nil {
+		t.Fatalf("artifact accepted with another key")
+	}
+
+	os.Chmod(info.Path, 0666)
+	if err := s.checkArtifact("x", info); err == nil {
+		t.Fatalf("writable artifact accepted")
+	}
+	ioutil.WriteFile(info.Path, []byte("injected"), 0644)
+	os.Chmod(info.Path, 0400)
+	if err := s.checkArtifact("x", info); err == nil {
+		t.Fatalf("modified artifact accepted")
+	}
+}
+
+func TestAotHostCallTable(t *testing.T) {
+	old := []byte("extern uint64_t GoFunc(vm_t*, const char*, int32_t, uint64_t*);\n" +
+		"static const char *env_func_names[] = {\"TC_BigIntAdd\"};\n" +
+		"r = GoFunc(vm, env_func_names[0], 2, &args0[0]);\n")
+	if err := checkHostCalls(old); err == nil {
+		t.Fatalf("host calls by name accepted")
+	}
+
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	wasmCode, err := ioutil.ReadFile("../testdata/malloc.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := state.New()
+	addr := types.BytesToAddress([]byte{1})
+	contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+	eng := NewEngine(contract, 10000, db, log.Test())
+	app, err := eng.NewApp(addr.String(), wasmCode, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := NewAotService("", false).generate(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(code), "GoFuncIndex(vm, ") {
+		t.Fatalf("generated code calls no host function by index:\n%s", code)
+	}
+
+	build := func(name string, funcs ...string) string {
+		src := filepath.Join(dir, name+".c")
+		lib := filepath.Join(dir, name+".so")
+		c := "#include <stdint.h>\n" +
+			"uint32_t thunderchain_main(void *vm, uint32_t action, uint32_t args) { return 0; }\n"
+		if funcs != nil {
+			c += hostTableC(funcs...)
+		}
+		if err := ioutil.WriteFile(src, []byte(c), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if out, err := exec.Command("gcc", "-shared", "-fPIC", "-o", lib, src).CombinedOutput(); err != nil {
+			t.Skipf("no C compiler: %v: %s", err, out)
+		}
+		return lib
+	}
+
+	imports := hostImports(app.Module)
+	native, err := NewNative(app, build("known", imports...))
+	if err != nil {
+		t.Fatalf("load library: %v", err)
+	}
+	defer native.close()
+	env := eng.Env
+	for i, name := range imports {
+		if got, _ := env.funcByIndex(native.dl.hosts[i]); got != name {
+			t.Fatalf("host %d resolved to %s, want %s", i, got, name)
+		}
+	}
+
+	// the generated code indexes the table by the imports of the module
+	for _, bad := range []struct {
+		name  string
+		funcs []string
+	}{
+		{"none", nil},
+		{"short", imports[:len(imports)-1]},
+		{"long", append(append([]string{}, imports...), "TC_BigIntAdd")},
+		{"reordered", append([]string{imports[1], imports[0]}, imports[2:]...)},
+		{"unknown", append(append([]string{}, imports[:len(imports)-1]...), "TC_NoSuchFunc")},
+	} {
+		if _, err := NewNative(app, build(bad.name, bad.funcs...)); err == nil {
+			t.Fatalf("library with the %s host function table loaded", bad.name)
+		}
+	}
+}
+
+// hostTableC returns the C code of a host function table listing funcs, as
+// generated by the wagon fork.
+func hostTableC(funcs ...string) string {
+	return "static const char *env_func_names[] = {\"" + strings.Join(funcs, "\", \"") + "\"};\n" +
+		string(envFuncsExport) + " {\n" +
+		"	*names = env_func_names;\n" +
+		fmt.Sprintf("	return %d;\n", len(funcs)) +
+		"}\n"
+}
+
+func TestAotPrecompile(t *testing.T) {
+	// not started, so the requests stay queued
+	q := NewAotService("", true)
+	q.queueSize = 1
+	a := &APP{Name: "a", codeHash: [32]byte{1}}
+	b := &APP{Name: "b", codeHash: [32]byte{2}}
+	q.checkApp(a)
+	q.checkApp(a)
+	q.request(b, true)
+	if st := q.Stats(); st.Queued != 2 || st.Dropped != 0 {
+		t.Fatalf("precompile request dropped: %+v", st)
+	}
+	if req := heap.Pop(&q.queue).(*aotRequest); req.app != b {
+		t.Fatalf("precompile request not first: %s", req.app.Name)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := q.wait(ctx, a); err != context.Canceled {
+		t.Fatalf("wait not canceled: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultAotConfig()
+	cfg.Root = dir
+	cfg.KeepCSource = false
+	s, err := StartAotService(cfg)
+	if err != nil {
+		t.Skipf("no C compiler: %v", err)
+	}
+	defer s.Stop()
+
+	code, err := ioutil.ReadFile("../testdata/malloc.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := state.New()
+	addr := types.BytesToAddress([]byte{1})
+	db.SetCode(addr, code)
+	contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+	eng := NewEngine(contract, 10000, db, log.Test())
+	eng.SetAotService(s)
+
+	if err := eng.WarmupAot(context.Background(), []string{addr.String()}); err != nil {
+		t.Fatalf("warm-up: %v", err)
+	}
+	app, err := eng.NewApp(addr.String(), nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.native == nil {
+		t.Fatalf("no native code after warm-up")
+	}
+	app.Close()
+	if err := eng.PrecompileApp(addr.String(), nil); err != nil {
+		t.Fatalf("precompile loaded contract: %v", err)
+	}
+
+	other := types.BytesToAddress([]byte{2})
+	if err := eng.WarmupAot(context.Background(), []string{other.String()}); err == nil {
+		t.Fatalf("warm-up of a contract without code succeeded")
+	}
+}
+
+func TestAotBlacklistRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewAotService(dir, true)
+	s.setCompiler("cc 1")
+	a := &APP{Name: "a", codeHash: [32]byte{1}, logger: log.Test()}
+	b := &APP{Name: "b", codeHash: [32]byte{2}, logger: log.Test()}
+	fa, fb := s.artifact(a), s.artifact(b)
+
+	s.lock.Lock()
+	s.addRef(a, fa)
+	s.addBlack(a, fa, &ArtifactInfo{Err: "Compile C Code Fail", Compiler: s.compiler})
+	s.addBlack(a, fa, &ArtifactInfo{Err: "Compile C Code Fail", Compiler: s.compiler})
+	s.addBlack(b, fb, &ArtifactInfo{Err: aotErrMismatch, Compiler: s.compiler})
+	ea, eb := s.black[fa], s.black[fb]
+	s.lock.Unlock()
+
+	if ea.Retries != 2 || ea.NextRetry.Sub(ea.Time) != 2*s.retryMin {
+		t.Fatalf("no exponential backoff: %+v", ea)
+	}
+	if s.retryDue(ea, ea.Time) || !s.retryDue(ea, ea.NextRetry) {
+		t.Fatalf("retry not due after the backoff: %+v", ea)
+	}
+	if !eb.NextRetry.IsZero() || s.retryDue(eb, eb.Time.Add(365*24*time.Hour)) {
+		t.Fatalf("mismatched artifact retried: %+v", eb)
+	}
+	if s.retryDelay(100) != s.retryMax {
+		t.Fatalf("backoff over the limit: %s", s.retryDelay(100))
+	}
+
+	s.request(b, false)
+	if st := s.Stats(); st.Queued != 0 {
+		t.Fatalf("blacklisted artifact queued: %+v", st)
+	}
+
+	// restored after restart, retried once the compiler changes
+	r := NewAotService(dir, true)
+	r.setCompiler("cc 1")
+	if err := r.loadBlacklist(); err != nil {
+		t.Fatal(err)
+	}
+	list := r.Blacklist()
+	if len(list) != 2 || list[0].Reason == "" || list[0].Compiler != "cc 1" {
+		t.Fatalf("blacklist not restored: %+v", list)
+	}
+	r.setCompiler("cc 2")
+	r.request(b, false)
+	if st := r.Stats(); st.Queued != 1 {
+		t.Fatalf("artifact not retried with another compiler: %+v", st)
+	}
+
+	// edited offline, without a service
+	files, err := ReadAotBlacklist(dir)
+	if err != nil || len(files) != 2 || files[0].Artifact != list[0].Artifact {
+		t.Fatalf("read blacklist: %+v, %v", files, err)
+	}
+	if err := WriteAotBlacklist(dir, files[1:]); err != nil {
+		t.Fatal(err)
+	}
+	r = NewAotService(dir, true)
+	if err := r.loadBlacklist(); err != nil {
+		t.Fatal(err)
+	}
+	if list := r.Blacklist(); len(list) != 1 || list[0].Artifact != files[1].Artifact {
+		t.Fatalf("blacklist not written: %+v", list)
+	}
+	if files, err := ReadAotBlacklist(filepath.Join(dir, "none")); err != nil || len(files) != 0 {
+		t.Fatalf("read missing blacklist: %+v, %v", files, err)
+	}
+
+	if n := s.ClearBlacklist("a"); n != 1 {
+		t.Fatalf("cleared %d artifacts by contract", n)
+	}
+	if err := s.Recompile("none"); err != ErrNoNative {
+		t.Fatalf("recompile unknown artifact: %v", err)
+	}
+	if err := s.Recompile(fb); err != nil {
+		t.Fatalf("recompile: %v", err)
+	}
+	if list := s.Blacklist(); len(list) != 0 {
+		t.Fatalf("blacklist not cleared: %+v", list)
+	}
+}
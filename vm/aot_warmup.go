@@ -0,0 +1,98 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+)
+
+// PrecompileApp compiles or loads the native code of contract name, with code
+// or the code in state if code is empty, ahead of the contracts queued by
+// runs, and waits until it is done. It returns ErrNoNative if the contract
+// has no usable native code.
+func (eng *Engine) PrecompileApp(name string, code []byte) error {
+	app, err := eng.NewApp(name, code, false)
+	if err != nil {
+		return err
+	}
+	eng.aots.request(app, true)
+	return WaitNative(context.Background(), app)
+}
+
+// WarmupAot precompiles the contracts names, in parallel on the workers of
+// the service, and waits until all are done or ctx is done. Contracts without
+// native code do not stop the others, the first error is returned.
+func (eng *Engine) WarmupAot(ctx context.Context, names []string) error {
+	if eng.aots == nil {
+		return ErrAotNotRunning
+	}
+
+	apps := make([]*APP, 0, len(names))
+	var first error
+	for _, name := range names {
+		app, err := eng.NewApp(name, nil, false)
+		if err != nil {
+			if first == nil {
+				first = fmt.Errorf("aot: warm-up %s: %v", name, err)
+			}
+			continue
+		}
+		eng.aots.request(app, true)
+		apps = append(apps, app)
+	}
+	for _, app := range apps {
+		if err := WaitNative(ctx, app); err != nil {
+			if err == ctx.Err() {
+				return err
+			}
+			if first == nil {
+				first = fmt.Errorf("aot: warm-up %s: %v", app.Name, err)
+			}
+		}
+	}
+	return first
+}
+
+// WaitNative waits until the native code of app, if queued, is compiled and
+// loaded. It returns ErrNoNative if app has no native code then.
+func WaitNative(ctx context.Context, app *APP) error {
+	return app.Eng.aots.wait(ctx, app)
+}
+
+func (s *AotService) wait(ctx context.Context, app *APP) error {
+	if s == nil {
+		return ErrAotNotRunning
+	}
+	name := s.artifact(app)
+
+	s.lock.Lock()
+	ready := s.ready[name]
+	s.lock.Unlock()
+
+	if ready == nil && s.stopped() {
+		return ErrAotNotRunning
+	}
+	if ready != nil {
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.exit:
+			return ErrAotNotRunning
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.succ[name] == nil {
+		return ErrNoNative
+	}
+	return nil
+}
+
+// finish wakes up the waiters of artifact name, the caller holds s.lock.
+func (s *AotService) finish(name string) {
+	if ready := s.ready[name]; ready != nil {
+		close(ready)
+		delete(s.ready, name)
+	}
+}
@@ -966,9 +966,7 @@ func tcJSONParse(eng *Engine, index int64, args []uint64) (uint64, error) {
 		return 0, err
 	}
 
-	root := len(eng.jsonCache)
-	eng.jsonCache = append(eng.jsonCache, obj)
-	return uint64(root), nil
+	return eng.newJSONObject(obj, len(data))
 }
 
 type TCJSONGetInt struct{}
@@ -987,16 +985,15 @@ func tcJSONGetInt(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 
 	v = bytes.Trim(v, "\"")
@@ -1024,16 +1021,15 @@ func tcJSONGetInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 
 	v = bytes.Trim(v, "\"")
@@ -1061,16 +1057,15 @@ func tcJSONGetString(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 	lenV := len(v)
 	v = v[1 : lenV-1]
@@ -1099,16 +1094,15 @@ func tcJSONGetAddress(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 	lenV := len(v)
 	v = v[1 : lenV-1]
@@ -1141,16 +1135,15 @@ func tcJSONGetBigInt(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 	lenV := len(v)
 	v = v[1 : lenV-1]
@@ -1184,16 +1177,15 @@ func tcJSONGetFloat(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 
 	v = bytes.Trim(v, "\"")
@@ -1221,16 +1213,15 @@ func tcJSONGetDouble(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	if len(v) == 0 {
-		return 0, fmt.Errorf("key(%s) not exist", string(key))
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
 	}
 
 	v = bytes.Trim(v, "\"")
@@ -1258,23 +1249,23 @@ func tcJSONGetObject(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
+	}
 
 	childObj := make(map[string]json.RawMessage)
 	if err = json.Unmarshal(v, &childObj); err != nil {
 		return 0, err
 	}
 
-	childIndex := len(eng.jsonCache)
-	eng.jsonCache = append(eng.jsonCache, childObj)
-	return uint64(childIndex), nil
+	return eng.newJSONObject(childObj, len(v))
 }
 
 type TCJSONNewObject struct{}
@@ -1290,10 +1281,7 @@ func (t *TCJSONNewObject) Gas(index int64, ops interface{}, args []uint64) (uint
 
 // c: void* TC_JsonNewObject()
 func tcJSONNewObject(eng *Engine, index int64, args []uint64) (uint64, error) {
-	obj := make(map[string]json.RawMessage)
-	i := len(eng.jsonCache)
-	eng.jsonCache = append(eng.jsonCache, obj)
-	return uint64(i), nil
+	return eng.newJSONObject(make(map[string]json.RawMessage), 0)
 }
 
 type TCJSONPutInt struct{}
@@ -1312,15 +1300,14 @@ func tcJSONPutInt(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	obj[string(key)], _ = json.Marshal(int(args[2]))
-	return 0, nil
+	data, _ := json.Marshal(int(args[2]))
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutInt64 struct{}
@@ -1339,19 +1326,18 @@ func tcJSONPutInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	obj[string(key)], err = json.Marshal(int64(args[2]))
+	data, err := json.Marshal(int64(args[2]))
 	if err != nil {
 		eng.logger.Error(" TC_JSONPutInt64", "key", string(key), "val", int64(args[2]), "err", err)
 		return 0, err
 	}
-	return 0, nil
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutString struct{}
@@ -1370,7 +1356,7 @@ func tcJSONPutString(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
@@ -1380,13 +1366,12 @@ func tcJSONPutString(eng *Engine, index int64, args []uint64) (uint64, error) {
 		return 0, err
 	}
 
-	obj := eng.jsonCache[root]
-	obj[string(key)], err = json.Marshal(string(val))
+	data, err := json.Marshal(string(val))
 	if err != nil {
 		eng.logger.Error(" TC_JSONPutString", "key", string(key), "val", string(val), "err", err)
 		return 0, err
 	}
-	return 0, nil
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutAddress struct{}
@@ -1405,7 +1390,7 @@ func tcJSONPutAddress(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
@@ -1417,13 +1402,12 @@ func tcJSONPutAddress(eng *Engine, index int64, args []uint64) (uint64, error) {
 	if !types.IsHexAddress(string(val)) {
 		return 0, fmt.Errorf("key(%s),value(%s) not address", string(key), string(val))
 	}
-	obj := eng.jsonCache[root]
-	obj[string(key)], err = json.Marshal(strings.ToLower(string(val)))
+	data, err := json.Marshal(strings.ToLower(string(val)))
 	if err != nil {
 		eng.logger.Error(" TC_JSONPutAddress", "key", string(key), "val", string(val), "err", err)
 		return 0, err
 	}
-	return 0, nil
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutBigInt struct{}
@@ -1442,7 +1426,7 @@ func tcJSONPutBigInt(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
@@ -1455,13 +1439,12 @@ func tcJSONPutBigInt(eng *Engine, index int64, args []uint64) (uint64, error) {
 	if !e1 {
 		return 0, fmt.Errorf("key(%s),value(%s) not BigInt type", string(key), string(val))
 	}
-	obj := eng.jsonCache[root]
-	obj[string(key)], err = json.Marshal(string(val))
+	data, err := json.Marshal(string(val))
 	if err != nil {
 		eng.logger.Error(" TC_JSONPutBigInt", "key", string(key), "val", string(val), "err", err)
 		return 0, err
 	}
-	return 0, nil
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutFloat struct{}
@@ -1480,16 +1463,15 @@ func tcJSONPutFloat(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 	val := math.Float32frombits(uint32(args[2]))
 
-	obj := eng.jsonCache[root]
-	obj[string(key)], _ = json.Marshal(val)
-	return 0, nil
+	data, _ := json.Marshal(val)
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutDouble struct{}
@@ -1508,16 +1490,15 @@ func tcJSONPutDouble(eng *Engine, index int64, args []uint64) (uint64, error) {
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, err := vmem.GetString(args[1])
 	if err != nil {
 		return 0, err
 	}
 	val := math.Float64frombits(args[2])
 
-	obj := eng.jsonCache[root]
-	obj[string(key)], _ = json.Marshal(val)
-	return 0, nil
+	data, _ := json.Marshal(val)
+	return 0, eng.jsonPut(root, string(key), data)
 }
 
 type TCJSONPutObject struct{}
@@ -1536,10 +1517,11 @@ func (t *TCJSONPutObject) Gas(index int64, ops interface{}, args []uint64) (gas
 	app, _ := eng.RunningAppFrame()
 	vmem := app.VM.VMemory()
 
-	root := int(args[0])
+	root := args[0]
 	key, _ := vmem.GetString(args[1])
-	rootObj := eng.jsonCache[root]
-	rootObj[string(key)] = json.RawMessage(data)
+	if err := eng.jsonPut(root, string(key), json.RawMessage(data)); err != nil {
+		return gas, err
+	}
 	return gas, nil
 }
 
@@ -1580,3 +1562,586 @@ func tcJSONToString(t *TCJSONToString, eng *Engine, index int64, args []uint64)
 	}
 	return uint64(pointer), nil
 }
+
+// ---------------------------------------------------------
+// go json array api (optional)
+
+type TCJSONParseArray struct{}
+
+func (t *TCJSONParseArray) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONParseArray(eng, index, args)
+}
+func (t *TCJSONParseArray) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONParseArray(eng, index, args)
+}
+
+// c: void *TC_JsonParseArray(char *data)
+func tcJSONParseArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	data, err := vmem.GetString(args[0])
+	if err != nil {
+		return 0, err
+	}
+
+	var arr []json.RawMessage
+	if err = json.Unmarshal(data, &arr); err != nil {
+		eng.logger.Error(" TC_JsonParseArray", "data", string(data), "err", err)
+		return 0, err
+	}
+	if arr == nil {
+		return 0, fmt.Errorf("data(%s) not array", string(data))
+	}
+	return eng.newJSONArray(arr, len(data))
+}
+
+type TCJSONArrayLen struct{}
+
+func (t *TCJSONArrayLen) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayLen(eng, index, args)
+}
+func (t *TCJSONArrayLen) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayLen(eng, index, args)
+}
+
+// c: int TC_JsonArrayLen(void *arr)
+func tcJSONArrayLen(eng *Engine, index int64, args []uint64) (uint64, error) {
+	arr, err := eng.jsonArray(args[0])
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(arr)), nil
+}
+
+// jsonArrayElem returns the element i of the array arr.
+func jsonArrayElem(eng *Engine, arr uint64, i uint64) (json.RawMessage, error) {
+	elems, err := eng.jsonArray(arr)
+	if err != nil {
+		return nil, err
+	}
+	n := int32(i)
+	if n < 0 || int(n) >= len(elems) {
+		return nil, fmt.Errorf("index(%d) out of range(%d)", n, len(elems))
+	}
+	return elems[n], nil
+}
+
+type TCJSONArrayGetInt struct{}
+
+func (t *TCJSONArrayGetInt) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayGetInt(eng, index, args)
+}
+func (t *TCJSONArrayGetInt) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayGetInt(eng, index, args)
+}
+
+// c: int TC_JsonArrayGetInt(void *arr, int index)
+func tcJSONArrayGetInt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	v = bytes.Trim(v, "\"")
+	i, err := strconv.ParseInt(string(v), 0, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(i), nil
+}
+
+type TCJSONArrayGetInt64 struct{}
+
+func (t *TCJSONArrayGetInt64) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayGetInt64(eng, index, args)
+}
+func (t *TCJSONArrayGetInt64) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayGetInt64(eng, index, args)
+}
+
+// c: long long TC_JsonArrayGetInt64(void *arr, int index)
+func tcJSONArrayGetInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	v = bytes.Trim(v, "\"")
+	i, err := strconv.ParseInt(string(v), 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(i), nil
+}
+
+type TCJSONArrayGetString struct{}
+
+func (t *TCJSONArrayGetString) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayGetString(eng, index, args)
+}
+func (t *TCJSONArrayGetString) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayGetString(eng, index, args)
+}
+
+// c: char *TC_JsonArrayGetString(void *arr, int index)
+func tcJSONArrayGetString(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	var s string
+	if err = json.Unmarshal(v, &s); err != nil {
+		return 0, fmt.Errorf("index(%d),value(%s) not string", int32(args[1]), string(v))
+	}
+
+	pointer, err := vmem.SetBytes([]byte(s))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(pointer), nil
+}
+
+type TCJSONArrayGetObject struct{}
+
+func (t *TCJSONArrayGetObject) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayGetObject(eng, index, args)
+}
+func (t *TCJSONArrayGetObject) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayGetObject(eng, index, args)
+}
+
+// c: void *TC_JsonArrayGetObject(void *arr, int index)
+func tcJSONArrayGetObject(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	childObj := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(v, &childObj); err != nil {
+		return 0, err
+	}
+
+	return eng.newJSONObject(childObj, len(v))
+}
+
+type TCJSONGetArray struct{}
+
+func (t *TCJSONGetArray) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetArray(eng, index, args)
+}
+func (t *TCJSONGetArray) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetArray(eng, index, args)
+}
+
+// c: void *TC_JsonGetArray(void *root, char *key)
+func tcJSONGetArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	root := args[0]
+	key, err := vmem.GetString(args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := eng.jsonGet(root, string(key))
+	if err != nil {
+		return 0, err
+	}
+
+	var arr []json.RawMessage
+	if err = json.Unmarshal(v, &arr); err != nil || arr == nil {
+		return 0, fmt.Errorf("key(%s),value(%s) not array", string(key), string(v))
+	}
+	return eng.newJSONArray(arr, len(v))
+}
+
+type TCJSONNewArray struct{}
+
+func (t *TCJSONNewArray) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONNewArray(eng, index, args)
+}
+func (t *TCJSONNewArray) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONNewArray(eng, index, args)
+}
+
+// c: void *TC_JsonNewArray()
+func tcJSONNewArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return eng.newJSONArray(nil, 0)
+}
+
+type TCJSONArrayPushInt struct{}
+
+func (t *TCJSONArrayPushInt) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayPushInt(eng, index, args)
+}
+func (t *TCJSONArrayPushInt) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayPushInt(eng, index, args)
+}
+
+// c: void TC_JsonArrayPushInt(void *arr, int value)
+func tcJSONArrayPushInt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, _ := json.Marshal(int32(args[1]))
+	return 0, eng.jsonPush(args[0], v)
+}
+
+type TCJSONArrayPushInt64 struct{}
+
+func (t *TCJSONArrayPushInt64) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayPushInt64(eng, index, args)
+}
+func (t *TCJSONArrayPushInt64) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayPushInt64(eng, index, args)
+}
+
+// c: void TC_JsonArrayPushInt64(void *arr, long long value)
+func tcJSONArrayPushInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, _ := json.Marshal(int64(args[1]))
+	return 0, eng.jsonPush(args[0], v)
+}
+
+type TCJSONArrayPushString struct{}
+
+func (t *TCJSONArrayPushString) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayPushString(eng, index, args)
+}
+func (t *TCJSONArrayPushString) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayPushString(eng, index, args)
+}
+
+// c: void TC_JsonArrayPushString(void *arr, char *value)
+func tcJSONArrayPushString(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	val, err := vmem.GetString(args[1])
+	if err != nil {
+		return 0, err
+	}
+	v, err := json.Marshal(string(val))
+	if err != nil {
+		eng.logger.Error(" TC_JsonArrayPushString", "val", string(val), "err", err)
+		return 0, err
+	}
+	return 0, eng.jsonPush(args[0], v)
+}
+
+type TCJSONArrayPushObject struct{}
+
+func (t *TCJSONArrayPushObject) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONArrayPushObject(eng, index, args)
+}
+func (t *TCJSONArrayPushObject) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONArrayPushObject(eng, index, args)
+}
+
+// c: void TC_JsonArrayPushObject(void *arr, void *child)
+func tcJSONArrayPushObject(eng *Engine, index int64, args []uint64) (uint64, error) {
+	child, err := eng.jsonObject(args[1])
+	if err != nil {
+		return 0, err
+	}
+	v, err := json.Marshal(child)
+	if err != nil {
+		return 0, err
+	}
+	return 0, eng.jsonPush(args[0], v)
+}
+
+type TCJSONPutArray struct{}
+
+func (t *TCJSONPutArray) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONPutArray(eng, index, args)
+}
+func (t *TCJSONPutArray) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONPutArray(eng, index, args)
+}
+
+// c: void TC_JsonPutArray(void *root, char *key, void *arr)
+func tcJSONPutArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	root := args[0]
+	key, err := vmem.GetString(args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	arr, err := eng.jsonArray(args[2])
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(arr)
+	if err != nil {
+		eng.logger.Error(" TC_JsonPutArray", "key", string(key), "err", err)
+		return 0, err
+	}
+	return 0, eng.jsonPut(root, string(key), data)
+}
+
+type TCJSONFree struct{}
+
+func (t *TCJSONFree) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONFree(eng, index, args)
+}
+func (t *TCJSONFree) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONFree(eng, index, args)
+}
+
+// c: void TC_JsonFree(void *root)
+func tcJSONFree(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return 0, eng.freeJSON(args[0])
+}
+
+// go json path api (optional)
+
+// jsonLookupArg returns the value at the key args[1] in the object args[0],
+// nil if there is none.
+func jsonLookupArg(eng *Engine, args []uint64) (json.RawMessage, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	key, err := vmem.GetString(args[1])
+	if err != nil {
+		return nil, err
+	}
+	v, _, err := eng.jsonLookup(args[0], string(key))
+	return v, err
+}
+
+func isJSONNull(v json.RawMessage) bool {
+	return string(v) == "null"
+}
+
+// parseJSONBool returns 1 for true and 0 for false, quoted or not.
+func parseJSONBool(v json.RawMessage) (uint64, error) {
+	switch string(bytes.Trim(v, "\"")) {
+	case "true":
+		return 1, nil
+	case "false":
+		return 0, nil
+	}
+	return 0, fmt.Errorf("value(%s) not bool", string(v))
+}
+
+type TCJSONHas struct{}
+
+func (t *TCJSONHas) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONHas(eng, index, args)
+}
+func (t *TCJSONHas) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONHas(eng, index, args)
+}
+
+// c: int TC_JsonHas(void *root, char *key)
+func tcJSONHas(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+type TCJSONIsNull struct{}
+
+func (t *TCJSONIsNull) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONIsNull(eng, index, args)
+}
+func (t *TCJSONIsNull) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONIsNull(eng, index, args)
+}
+
+// c: int TC_JsonIsNull(void *root, char *key)
+func tcJSONIsNull(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if !isJSONNull(v) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+type TCJSONGetBool struct{}
+
+func (t *TCJSONGetBool) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetBool(eng, index, args)
+}
+func (t *TCJSONGetBool) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetBool(eng, index, args)
+}
+
+// c: int TC_JsonGetBool(void *root, char *key)
+func tcJSONGetBool(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	key, err := vmem.GetString(args[1])
+	if err != nil {
+		return 0, err
+	}
+	v, err := eng.jsonGet(args[0], string(key))
+	if err != nil {
+		return 0, err
+	}
+	return parseJSONBool(v)
+}
+
+type TCJSONGetIntOr struct{}
+
+func (t *TCJSONGetIntOr) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetIntOr(eng, index, args)
+}
+func (t *TCJSONGetIntOr) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetIntOr(eng, index, args)
+}
+
+// c: int TC_JsonGetIntOr(void *root, char *key, int def)
+func tcJSONGetIntOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || isJSONNull(v) {
+		return args[2], nil
+	}
+
+	v = bytes.Trim(v, "\"")
+	i, err := strconv.ParseInt(string(v), 0, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(i), nil
+}
+
+type TCJSONGetInt64Or struct{}
+
+func (t *TCJSONGetInt64Or) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetInt64Or(eng, index, args)
+}
+func (t *TCJSONGetInt64Or) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetInt64Or(eng, index, args)
+}
+
+// c: long long TC_JsonGetInt64Or(void *root, char *key, long long def)
+func tcJSONGetInt64Or(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || isJSONNull(v) {
+		return args[2], nil
+	}
+
+	v = bytes.Trim(v, "\"")
+	i, err := strconv.ParseInt(string(v), 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(i), nil
+}
+
+type TCJSONGetBoolOr struct{}
+
+func (t *TCJSONGetBoolOr) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetBoolOr(eng, index, args)
+}
+func (t *TCJSONGetBoolOr) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetBoolOr(eng, index, args)
+}
+
+// c: int TC_JsonGetBoolOr(void *root, char *key, int def)
+func tcJSONGetBoolOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || isJSONNull(v) {
+		return args[2], nil
+	}
+	return parseJSONBool(v)
+}
+
+type TCJSONGetStringOr struct{}
+
+func (t *TCJSONGetStringOr) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcJSONGetStringOr(eng, index, args)
+}
+func (t *TCJSONGetStringOr) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasJSONGetStringOr(eng, index, args)
+}
+
+// c: char * TC_JsonGetStringOr(void *root, char *key, char *def)
+func tcJSONGetStringOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	v, err := jsonLookupArg(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 || isJSONNull(v) {
+		return args[2], nil
+	}
+	if len(v) < 2 || v[0] != '"' {
+		return 0, fmt.Errorf("value(%s) not string", string(v))
+	}
+
+	pointer, err := vmem.SetBytes([]byte(v[1 : len(v)-1]))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(pointer), nil
+}
@@ -1,8 +1,14 @@
 package vm
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/sha256"
 	"fmt"
+	"math/big"
+
+	"github.com/xunleichain/tc-wasm/mock/deps/bn256"
 	"github.com/xunleichain/tc-wasm/mock/types"
 	"golang.org/x/crypto/ripemd160"
 	"golang.org/x/crypto/sha3"
@@ -293,3 +299,214 @@ func tcIsHexAddress(eng *Engine, index int64, args []uint64) (uint64, error) {
 	}
 	return ret, err
 }
+
+// bn256Input returns the input of a bn256 call, right padded with zeros to
+// size bytes as the input of the precompiled contracts.
+func bn256Input(eng *Engine, args []uint64, size int) ([]byte, error) {
+	runningFrame, _ := eng.RunningAppFrame()
+	vmem := runningFrame.VM.VMemory()
+	n := int(int32(args[1]))
+	if n < 0 {
+		return nil, ErrInvalidApiArgs
+	}
+	data, err := vmem.GetBytes(args[0], n)
+	if err != nil {
+		return nil, ErrInvalidApiArgs
+	}
+	if len(data) < size {
+		data = append(data, make([]byte, size-len(data))...)
+	}
+	return data, nil
+}
+
+func bn256G1(data []byte) (*bn256.G1, error) {
+	p, ok := new(bn256.G1).Unmarshal(data)
+	if !ok {
+		return nil, ErrBn256Point
+	}
+	return p, nil
+}
+
+type TCBn256Add struct{}
+
+func (t *TCBn256Add) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBn256Add(eng, index, args)
+}
+func (t *TCBn256Add) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBn256Add(eng, index, args)
+}
+
+// TC_Bn256Add returns the 64 bytes of the sum of the two points of input, as
+// the precompiled contract at address 6.
+// void *TC_Bn256Add(void *input, int len)
+func tcBn256Add(eng *Engine, index int64, args []uint64) (uint64, error) {
+	input, err := bn256Input(eng, args, 128)
+	if err != nil {
+		return 0, err
+	}
+	a, err := bn256G1(input[:64])
+	if err != nil {
+		return 0, err
+	}
+	b, err := bn256G1(input[64:128])
+	if err != nil {
+		return 0, err
+	}
+	runningFrame, _ := eng.RunningAppFrame()
+	return runningFrame.VM.VMemory().SetBytes(new(bn256.G1).Add(a, b).Marshal())
+}
+
+type TCBn256ScalarMul struct{}
+
+func (t *TCBn256ScalarMul) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBn256ScalarMul(eng, index, args)
+}
+func (t *TCBn256ScalarMul) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBn256ScalarMul(eng, index, args)
+}
+
+// TC_Bn256ScalarMul returns the 64 bytes of the point of input multiplied by
+// its scalar, as the precompiled contract at address 7.
+// void *TC_Bn256ScalarMul(void *input, int len)
+func tcBn256ScalarMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	input, err := bn256Input(eng, args, 96)
+	if err != nil {
+		return 0, err
+	}
+	a, err := bn256G1(input[:64])
+	if err != nil {
+		return 0, err
+	}
+	k := new(big.Int).SetBytes(input[64:96])
+	runningFrame, _ := eng.RunningAppFrame()
+	return runningFrame.VM.VMemory().SetBytes(new(bn256.G1).ScalarMult(a, k).Marshal())
+}
+
+type TCBn256Pairing struct{}
+
+func (t *TCBn256Pairing) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBn256Pairing(eng, index, args)
+}
+func (t *TCBn256Pairing) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBn256Pairing(eng, index, args)
+}
+
+// TC_Bn256Pairing returns 1 if the product of the pairings of the (G1, G2)
+// pairs of input is one, 0 if not, as the precompiled contract at address 8.
+// int TC_Bn256Pairing(void *input, int len)
+func tcBn256Pairing(eng *Engine, index int64, args []uint64) (uint64, error) {
+	input, err := bn256Input(eng, args, 0)
+	if err != nil {
+		return 0, err
+	}
+	if len(input)%192 != 0 {
+		return 0, ErrInvalidApiArgs
+	}
+	var (
+		cs []*bn256.G1
+		ts []*bn256.G2
+	)
+	for i := 0; i < len(input); i += 192 {
+		c, err := bn256G1(input[i : i+64])
+		if err != nil {
+			return 0, err
+		}
+		t, ok := new(bn256.G2).Unmarshal(input[i+64 : i+192])
+		if !ok {
+			return 0, ErrBn256Point
+		}
+		cs = append(cs, c)
+		ts = append(ts, t)
+	}
+	if bn256.PairingCheck(cs, ts) {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// sigVerifyArgs returns the message of args[0] with len args[1], the key of
+// args[keyArg] and the 64 bytes signature of args[sigArg].
+func sigVerifyArgs(eng *Engine, args []uint64, keyArg, keyLen, sigArg int) (msg, key, sig []byte, err error) {
+	runningFrame, _ := eng.RunningAppFrame()
+	vmem := runningFrame.VM.VMemory()
+	n := int(int32(args[1]))
+	if n < 0 {
+		return nil, nil, nil, ErrInvalidApiArgs
+	}
+	if msg, err = vmem.GetBytes(args[0], n); err != nil {
+		return nil, nil, nil, ErrInvalidApiArgs
+	}
+	if key, err = vmem.GetBytes(args[keyArg], keyLen); err != nil {
+		return nil, nil, nil, ErrInvalidApiArgs
+	}
+	if sig, err = vmem.GetBytes(args[sigArg], 64); err != nil {
+		return nil, nil, nil, ErrInvalidApiArgs
+	}
+	return msg, key, sig, nil
+}
+
+type TCEd25519Verify struct{}
+
+func (t *TCEd25519Verify) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcEd25519Verify(eng, index, args)
+}
+func (t *TCEd25519Verify) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasEd25519Verify(eng, index, args)
+}
+
+// TC_Ed25519Verify returns 1 if sig is the 64 bytes ed25519 signature of msg
+// by the 32 bytes public key, 0 if not.
+// int TC_Ed25519Verify(void *msg, int len, void *pubkey, void *sig)
+func tcEd25519Verify(eng *Engine, index int64, args []uint64) (uint64, error) {
+	msg, pubkey, sig, err := sigVerifyArgs(eng, args, 2, ed25519.PublicKeySize, 3)
+	if err != nil {
+		return 0, err
+	}
+	if ed25519.Verify(ed25519.PublicKey(pubkey), msg, sig) {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+type TCP256Verify struct{}
+
+func (t *TCP256Verify) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcP256Verify(eng, index, args)
+}
+func (t *TCP256Verify) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasP256Verify(eng, index, args)
+}
+
+// TC_P256Verify returns 1 if sig (r || s) is the ECDSA signature on the
+// secp256r1 curve of the SHA-256 hash of msg by the public key (x || y), as
+// the ES256 signatures of WebAuthn, 0 if not.
+// int TC_P256Verify(void *msg, int len, void *pubkey, void *sig)
+func tcP256Verify(eng *Engine, index int64, args []uint64) (uint64, error) {
+	msg, pubkey, sig, err := sigVerifyArgs(eng, args, 2, 64, 3)
+	if err != nil {
+		return 0, err
+	}
+	x := new(big.Int).SetBytes(pubkey[:32])
+	y := new(big.Int).SetBytes(pubkey[32:])
+	curve := elliptic.P256()
+	if !curve.IsOnCurve(x, y) {
+		return 0, nil
+	}
+	hash := sha256.Sum256(msg)
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, hash[:], r, s) {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -2,7 +2,7 @@ package vm
 
 import (
 	"bytes"
-	"crypto/md5"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -45,10 +45,12 @@ type APP struct {
 	EntryFunc string
 
 	native *Native
+	dbg    *debugInfo
+	json   *jsonTable // handles of the TC_Json api, per frame
 
 	result interface{}
 
-	md5 [16]byte
+	codeHash [32]byte // sha256 of the wasm code
 }
 
 // Clone just copy
@@ -63,22 +65,31 @@ func (app *APP) Clone(eng *Engine) *APP {
 		VM:        vm,
 		VmProcess: exec.NewProcess(vm),
 		EntryFunc: app.EntryFunc,
-		md5:       app.md5,
+		dbg:       app.dbg,
+		codeHash:  app.codeHash,
 	}
-	newApp.native = GetNative(newApp)
+	newApp.native = eng.aots.getNative(newApp)
 	if newApp.native == nil {
-		RefreshApp(app)
+		eng.aots.checkApp(app)
 	}
 	return newApp
 }
 
+// runsNative reports whether Run takes the AOT compiled code.
+func (app *APP) runsNative() bool {
+	if app.native == nil || (app.Eng != nil && app.Eng.interpreterOnly()) {
+		return false
+	}
+	return app.native.sandbox == nil || app.native.canFallBack()
+}
+
 // Close --
 func (app *APP) Close() {
 	app.native.close()
 }
 
 func (app *APP) String() string {
-	return fmt.Sprintf("%s-%s", app.Name, hex.EncodeToString(app.md5[:]))
+	return fmt.Sprintf("%s-%s", app.Name, hex.EncodeToString(app.codeHash[:]))
 }
 
 // NewApp new wasm app module
@@ -100,8 +111,6 @@ func NewApp(name string, code []byte, eng *Engine, debug bool, logger log.Logger
 		return nil, fmt.Errorf("validate.VerifyMoudle fail: %s", err)
 	}
 
-	md5 := md5.Sum(code)
-
 	app := &APP{
 		logger:    logger,
 		Name:      name,
@@ -109,7 +118,8 @@ func NewApp(name string, code []byte, eng *Engine, debug bool, logger log.Logger
 		Module:    m,
 		Eng:       eng,
 		EntryFunc: APPEntry,
-		md5:       md5,
+		dbg:       newDebugInfo(m, code),
+		codeHash:  sha256.Sum256(code),
 	}
 
 	vm, err := exec.NewVM(m, eng)
@@ -209,8 +219,14 @@ func ParseInitArgsAndCode(data []byte) ([]byte, []byte, error) {
 // Run execute AppEntry Function
 // the input format should be "action | args"
 func (app *APP) Run(action, args string) (uint64, error) {
-	if !app.IsPreRun && app.native != nil {
-		return app.native.RunCMain(action, args)
+	if !app.IsPreRun && app.runsNative() {
+		ret, err := app.native.RunCMain(action, args)
+		if err != errSandboxDied {
+			return ret, err
+		}
+		app.Eng.aots.blacklist(app, aotErrCrash)
+		app.native.close()
+		app.native = nil
 	}
 
 	if app.IsPreRun {
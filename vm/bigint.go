@@ -0,0 +1,707 @@
+package vm
+
+import (
+	"math/big"
+)
+
+// The extended TC_BigInt api takes a mode as its last argument:
+//   0: unbounded, as TC_BigIntAdd and co, with results of up to MaxBigIntBits
+//   1: uint256, values in [0, 2^256)
+//   2: int256, values in [-2^255, 2^255)
+// Arguments out of the range of the mode fail with ErrBigIntOverflow, and so
+// do results, as the checked arithmetic of the EVM contracts: the bitwise
+// operations and the left shift wrap around instead. Division and modulo
+// truncate toward zero in int256 mode, as SDIV and SMOD. Unlike the older
+// api, arguments which are not numbers fail with ErrBigIntInvalid.
+
+const (
+	bigIntModeNone    = 0
+	bigIntModeUint256 = 1
+	bigIntModeInt256  = 2
+)
+
+var (
+	bigInt2p255  = new(big.Int).Lsh(big.NewInt(1), 255)
+	bigInt2p256  = new(big.Int).Lsh(big.NewInt(1), 256)
+	bigIntMax256 = new(big.Int).Sub(bigInt2p256, big.NewInt(1))
+)
+
+// bigIntExtOp computes the result of an extended TC_BigInt call.
+type bigIntExtOp func(eng *Engine, args []uint64) (*big.Int, error)
+
+func bigIntMode(arg uint64) (int, error) {
+	mode := int(int32(arg))
+	if mode < bigIntModeNone || mode > bigIntModeInt256 {
+		return 0, ErrBigIntMode
+	}
+	return mode, nil
+}
+
+// bigIntBits returns the bits of the values of mode.
+func bigIntBits(mode int) int {
+	if mode == bigIntModeNone {
+		return MaxBigIntBits
+	}
+	return 256
+}
+
+// bigIntStringLen returns a bound of the length of the decimal string of a
+// number of bits, with its sign.
+func bigIntStringLen(bits int) uint64 {
+	// 30103/100000 > log10(2)
+	return uint64(bits)*30103/100000 + 2
+}
+
+func bigIntFits(x *big.Int, mode int) bool {
+	switch mode {
+	case bigIntModeUint256:
+		return x.Sign() >= 0 && x.BitLen() <= 256
+	case bigIntModeInt256:
+		return x.Cmp(bigInt2p255) < 0 && x.Cmp(new(big.Int).Neg(bigInt2p255)) >= 0
+	}
+	return x.BitLen() <= MaxBigIntBits
+}
+
+// bigIntWrap returns x modulo 2^256 in the range of mode, as two's complement
+// in int256 mode.
+func bigIntWrap(x *big.Int, mode int) *big.Int {
+	if mode == bigIntModeNone {
+		return x
+	}
+	x.And(x, bigIntMax256)
+	if mode == bigIntModeInt256 && x.Bit(255) == 1 {
+		x.Sub(x, bigInt2p256)
+	}
+	return x
+}
+
+// bigIntCheck fails if x is out of the range of mode.
+func bigIntCheck(x *big.Int, mode int) (*big.Int, error) {
+	if !bigIntFits(x, mode) {
+		return nil, ErrBigIntOverflow
+	}
+	return x, nil
+}
+
+// bigIntArgs reads the numbers at ptrs and the mode of the call.
+func bigIntArgs(eng *Engine, ptrs []uint64, modeArg uint64) ([]*big.Int, int, error) {
+	mode, err := bigIntMode(modeArg)
+	if err != nil {
+		return nil, 0, err
+	}
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	xs := make([]*big.Int, len(ptrs))
+	for i, ptr := range ptrs {
+		s, err := vmem.GetString(ptr)
+		if err != nil {
+			return nil, 0, ErrMemoryGet
+		}
+		x, ok := new(big.Int).SetString(string(s), 0)
+		if !ok {
+			return nil, 0, ErrBigIntInvalid
+		}
+		if !bigIntFits(x, mode) {
+			return nil, 0, ErrBigIntOverflow
+		}
+		xs[i] = x
+	}
+	return xs, mode, nil
+}
+
+// tcBigIntExt returns the result of op as a decimal string.
+func tcBigIntExt(eng *Engine, args []uint64, op bigIntExtOp) (uint64, error) {
+	x, err := op(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	app, _ := eng.RunningAppFrame()
+	ptr, err := app.VM.VMemory().SetBytes([]byte(x.String()))
+	if err != nil {
+		return 0, ErrMemorySet
+	}
+	return ptr, nil
+}
+
+func bigIntChecked(eng *Engine, args []uint64, op bigIntOpType) (*big.Int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	a, b := xs[0], xs[1]
+	switch op {
+	case bigIntOpAdd:
+		a.Add(a, b)
+	case bigIntOpSub:
+		a.Sub(a, b)
+	case bigIntOpMul:
+		a.Mul(a, b)
+	case bigIntOpDiv, bigIntOpMod:
+		if b.Sign() == 0 {
+			return nil, ErrBigIntDivZero
+		}
+		switch {
+		case op == bigIntOpDiv && mode == bigIntModeInt256:
+			a.Quo(a, b)
+		case op == bigIntOpDiv:
+			a.Div(a, b)
+		case mode == bigIntModeInt256:
+			a.Rem(a, b)
+		default:
+			a.Mod(a, b)
+		}
+	}
+	return bigIntCheck(a, mode)
+}
+
+func bigIntCheckedAdd(eng *Engine, args []uint64) (*big.Int, error) {
+	return bigIntChecked(eng, args, bigIntOpAdd)
+}
+
+func bigIntCheckedSub(eng *Engine, args []uint64) (*big.Int, error) {
+	return bigIntChecked(eng, args, bigIntOpSub)
+}
+
+func bigIntCheckedMul(eng *Engine, args []uint64) (*big.Int, error) {
+	return bigIntChecked(eng, args, bigIntOpMul)
+}
+
+func bigIntCheckedDiv(eng *Engine, args []uint64) (*big.Int, error) {
+	return bigIntChecked(eng, args, bigIntOpDiv)
+}
+
+func bigIntCheckedMod(eng *Engine, args []uint64) (*big.Int, error) {
+	return bigIntChecked(eng, args, bigIntOpMod)
+}
+
+func bigIntPow(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	a, b := xs[0], xs[1]
+	if b.Sign() < 0 {
+		return nil, ErrBigIntInvalid
+	}
+	// |a| >= 2 has at least (bits(a)-1)*b bits raised to b: fail before
+	// computing a result too large for the mode.
+	if n := a.BitLen() - 1; n > 0 && (!b.IsInt64() || b.Int64() > int64(bigIntBits(mode)/n)) {
+		return nil, ErrBigIntOverflow
+	}
+	return bigIntCheck(a.Exp(a, b, nil), mode)
+}
+
+func bigIntModExp(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:3], args[3])
+	if err != nil {
+		return nil, err
+	}
+	b, e, m := xs[0], xs[1], xs[2]
+	if m.Sign() == 0 {
+		return nil, ErrBigIntDivZero
+	}
+	if e.Sign() < 0 || m.Sign() < 0 {
+		return nil, ErrBigIntInvalid
+	}
+	b.Exp(b, e, m)
+	return bigIntCheck(b.Mod(b, m), mode)
+}
+
+func bigIntSqrt(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:1], args[1])
+	if err != nil {
+		return nil, err
+	}
+	if xs[0].Sign() < 0 {
+		return nil, ErrBigIntInvalid
+	}
+	return xs[0].Sqrt(xs[0]), nil
+}
+
+func bigIntAbs(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:1], args[1])
+	if err != nil {
+		return nil, err
+	}
+	return bigIntCheck(xs[0].Abs(xs[0]), mode)
+}
+
+func bigIntAnd(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	return xs[0].And(xs[0], xs[1]), nil
+}
+
+func bigIntOr(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	return xs[0].Or(xs[0], xs[1]), nil
+}
+
+func bigIntXor(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	return xs[0].Xor(xs[0], xs[1]), nil
+}
+
+func bigIntNot(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:1], args[1])
+	if err != nil {
+		return nil, err
+	}
+	if mode == bigIntModeUint256 {
+		return xs[0].Sub(bigIntMax256, xs[0]), nil
+	}
+	return xs[0].Not(xs[0]), nil
+}
+
+// bigIntShift returns the number and the shift of a shift call.
+func bigIntShift(eng *Engine, args []uint64) (*big.Int, uint, int, error) {
+	xs, mode, err := bigIntArgs(eng, args[:1], args[2])
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	n := int32(args[1])
+	if n < 0 {
+		return nil, 0, 0, ErrBigIntInvalid
+	}
+	return xs[0], uint(n), mode, nil
+}
+
+func bigIntShl(eng *Engine, args []uint64) (*big.Int, error) {
+	a, n, mode, err := bigIntShift(eng, args)
+	if err != nil {
+		return nil, err
+	}
+	if a.Sign() == 0 {
+		return a, nil
+	}
+	if mode == bigIntModeNone {
+		if a.BitLen()+int(n) > MaxBigIntBits {
+			return nil, ErrBigIntOverflow
+		}
+		return a.Lsh(a, n), nil
+	}
+	if n >= 256 {
+		return a.SetInt64(0), nil
+	}
+	return bigIntWrap(a.Lsh(a, n), mode), nil
+}
+
+func bigIntShr(eng *Engine, args []uint64) (*big.Int, error) {
+	a, n, _, err := bigIntShift(eng, args)
+	if err != nil {
+		return nil, err
+	}
+	return a.Rsh(a, n), nil
+}
+
+func bigIntMin(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	if xs[1].Cmp(xs[0]) < 0 {
+		return xs[1], nil
+	}
+	return xs[0], nil
+}
+
+func bigIntMax(eng *Engine, args []uint64) (*big.Int, error) {
+	xs, _, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return nil, err
+	}
+	if xs[1].Cmp(xs[0]) > 0 {
+		return xs[1], nil
+	}
+	return xs[0], nil
+}
+
+// bigIntByteSize returns the size of a bytes conversion, 1 to 32 bytes.
+func bigIntByteSize(arg uint64) (int, error) {
+	size := int(int32(arg))
+	if size < 1 || size > 32 {
+		return 0, ErrInvalidApiArgs
+	}
+	return size, nil
+}
+
+// bigIntToBytes returns x as size big-endian bytes, two's complement in
+// int256 mode.
+func bigIntToBytes(x *big.Int, size int, mode int) ([]byte, error) {
+	bits := uint(size * 8)
+	if mode == bigIntModeInt256 {
+		limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		if x.Cmp(limit) >= 0 || x.Cmp(limit.Neg(limit)) < 0 {
+			return nil, ErrBigIntOverflow
+		}
+		if x.Sign() < 0 {
+			x = new(big.Int).Add(x, new(big.Int).Lsh(big.NewInt(1), bits))
+		}
+	} else if x.Sign() < 0 || x.BitLen() > int(bits) {
+		return nil, ErrBigIntOverflow
+	}
+	b := x.Bytes()
+	data := make([]byte, size)
+	copy(data[size-len(b):], b)
+	return data, nil
+}
+
+// bigIntFromBytes returns the big-endian data, two's complement in int256
+// mode.
+func bigIntFromBytes(data []byte, mode int) *big.Int {
+	x := new(big.Int).SetBytes(data)
+	if mode == bigIntModeInt256 && len(data) > 0 && data[0]&0x80 != 0 {
+		x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
+	return x
+}
+
+type TCBigIntCheckedAdd struct{}
+
+func (t *TCBigIntCheckedAdd) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntCheckedAdd(eng, index, args)
+}
+func (t *TCBigIntCheckedAdd) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntCheckedAdd(eng, index, args)
+}
+
+// c: char *TC_BigIntCheckedAdd(char *a, char *b, int mode)
+func tcBigIntCheckedAdd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntCheckedAdd)
+}
+
+type TCBigIntCheckedSub struct{}
+
+func (t *TCBigIntCheckedSub) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntCheckedSub(eng, index, args)
+}
+func (t *TCBigIntCheckedSub) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntCheckedSub(eng, index, args)
+}
+
+// c: char *TC_BigIntCheckedSub(char *a, char *b, int mode)
+func tcBigIntCheckedSub(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntCheckedSub)
+}
+
+type TCBigIntCheckedMul struct{}
+
+func (t *TCBigIntCheckedMul) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntCheckedMul(eng, index, args)
+}
+func (t *TCBigIntCheckedMul) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntCheckedMul(eng, index, args)
+}
+
+// c: char *TC_BigIntCheckedMul(char *a, char *b, int mode)
+func tcBigIntCheckedMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntCheckedMul)
+}
+
+type TCBigIntCheckedDiv struct{}
+
+func (t *TCBigIntCheckedDiv) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntCheckedDiv(eng, index, args)
+}
+func (t *TCBigIntCheckedDiv) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntCheckedDiv(eng, index, args)
+}
+
+// c: char *TC_BigIntCheckedDiv(char *a, char *b, int mode)
+func tcBigIntCheckedDiv(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntCheckedDiv)
+}
+
+type TCBigIntCheckedMod struct{}
+
+func (t *TCBigIntCheckedMod) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntCheckedMod(eng, index, args)
+}
+func (t *TCBigIntCheckedMod) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntCheckedMod(eng, index, args)
+}
+
+// c: char *TC_BigIntCheckedMod(char *a, char *b, int mode)
+func tcBigIntCheckedMod(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntCheckedMod)
+}
+
+type TCBigIntPow struct{}
+
+func (t *TCBigIntPow) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntPow(eng, index, args)
+}
+func (t *TCBigIntPow) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntPow(eng, index, args)
+}
+
+// c: char *TC_BigIntPow(char *a, char *b, int mode)
+func tcBigIntPow(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntPow)
+}
+
+type TCBigIntModExp struct{}
+
+func (t *TCBigIntModExp) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntModExp(eng, index, args)
+}
+func (t *TCBigIntModExp) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntModExp(eng, index, args)
+}
+
+// c: char *TC_BigIntModExp(char *b, char *e, char *m, int mode)
+func tcBigIntModExp(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntModExp)
+}
+
+type TCBigIntSqrt struct{}
+
+func (t *TCBigIntSqrt) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntSqrt(eng, index, args)
+}
+func (t *TCBigIntSqrt) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntSqrt(eng, index, args)
+}
+
+// c: char *TC_BigIntSqrt(char *a, int mode)
+func tcBigIntSqrt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntSqrt)
+}
+
+type TCBigIntAbs struct{}
+
+func (t *TCBigIntAbs) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntAbs(eng, index, args)
+}
+func (t *TCBigIntAbs) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntAbs(eng, index, args)
+}
+
+// c: char *TC_BigIntAbs(char *a, int mode)
+func tcBigIntAbs(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntAbs)
+}
+
+type TCBigIntAnd struct{}
+
+func (t *TCBigIntAnd) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntAnd(eng, index, args)
+}
+func (t *TCBigIntAnd) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntAnd(eng, index, args)
+}
+
+// c: char *TC_BigIntAnd(char *a, char *b, int mode)
+func tcBigIntAnd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntAnd)
+}
+
+type TCBigIntOr struct{}
+
+func (t *TCBigIntOr) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntOr(eng, index, args)
+}
+func (t *TCBigIntOr) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntOr(eng, index, args)
+}
+
+// c: char *TC_BigIntOr(char *a, char *b, int mode)
+func tcBigIntOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntOr)
+}
+
+type TCBigIntXor struct{}
+
+func (t *TCBigIntXor) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntXor(eng, index, args)
+}
+func (t *TCBigIntXor) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntXor(eng, index, args)
+}
+
+// c: char *TC_BigIntXor(char *a, char *b, int mode)
+func tcBigIntXor(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntXor)
+}
+
+type TCBigIntNot struct{}
+
+func (t *TCBigIntNot) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntNot(eng, index, args)
+}
+func (t *TCBigIntNot) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntNot(eng, index, args)
+}
+
+// c: char *TC_BigIntNot(char *a, int mode)
+func tcBigIntNot(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntNot)
+}
+
+type TCBigIntShl struct{}
+
+func (t *TCBigIntShl) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntShl(eng, index, args)
+}
+func (t *TCBigIntShl) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntShl(eng, index, args)
+}
+
+// c: char *TC_BigIntShl(char *a, int n, int mode)
+func tcBigIntShl(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntShl)
+}
+
+type TCBigIntShr struct{}
+
+func (t *TCBigIntShr) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntShr(eng, index, args)
+}
+func (t *TCBigIntShr) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntShr(eng, index, args)
+}
+
+// c: char *TC_BigIntShr(char *a, int n, int mode)
+func tcBigIntShr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntShr)
+}
+
+type TCBigIntMin struct{}
+
+func (t *TCBigIntMin) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntMin(eng, index, args)
+}
+func (t *TCBigIntMin) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntMin(eng, index, args)
+}
+
+// c: char *TC_BigIntMin(char *a, char *b, int mode)
+func tcBigIntMin(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntMin)
+}
+
+type TCBigIntMax struct{}
+
+func (t *TCBigIntMax) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntMax(eng, index, args)
+}
+func (t *TCBigIntMax) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntMax(eng, index, args)
+}
+
+// c: char *TC_BigIntMax(char *a, char *b, int mode)
+func tcBigIntMax(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcBigIntExt(eng, args, bigIntMax)
+}
+
+type TCBigIntToBytes struct{}
+
+func (t *TCBigIntToBytes) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntToBytes(eng, index, args)
+}
+func (t *TCBigIntToBytes) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntToBytes(eng, index, args)
+}
+
+// c: void *TC_BigIntToBytes(char *a, int size, int mode)
+func tcBigIntToBytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	size, err := bigIntByteSize(args[1])
+	if err != nil {
+		return 0, err
+	}
+	xs, mode, err := bigIntArgs(eng, args[:1], args[2])
+	if err != nil {
+		return 0, err
+	}
+	data, err := bigIntToBytes(xs[0], size, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	app, _ := eng.RunningAppFrame()
+	ptr, err := app.VM.VMemory().SetBytes(data)
+	if err != nil {
+		return 0, ErrMemorySet
+	}
+	return ptr, nil
+}
+
+type TCBigIntFromBytes struct{}
+
+func (t *TCBigIntFromBytes) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBigIntFromBytes(eng, index, args)
+}
+func (t *TCBigIntFromBytes) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBigIntFromBytes(eng, index, args)
+}
+
+// c: char *TC_BigIntFromBytes(void *data, int size, int mode)
+func tcBigIntFromBytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	size, err := bigIntByteSize(args[1])
+	if err != nil {
+		return 0, err
+	}
+	mode, err := bigIntMode(args[2])
+	if err != nil {
+		return 0, err
+	}
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	data, err := vmem.GetBytes(args[0], size)
+	if err != nil {
+		return 0, ErrMemoryGet
+	}
+	ptr, err := vmem.SetBytes([]byte(bigIntFromBytes(data, mode).String()))
+	if err != nil {
+		return 0, ErrMemorySet
+	}
+	return ptr, nil
+}
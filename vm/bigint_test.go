@@ -0,0 +1,190 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBigIntExt(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	const (
+		none   = bigIntModeNone
+		u256   = bigIntModeUint256
+		i256   = bigIntModeInt256
+		max256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+		max255 = "57896044618658097711785492504343953926634992332820282019728792003956564819967"
+		min255 = "-57896044618658097711785492504343953926634992332820282019728792003956564819968"
+	)
+	type call func(*Engine, int64, []uint64) (uint64, error)
+	tests := []struct {
+		name string
+		fn   call
+		args []string // numbers, then the int args
+		ints []uint64
+		want string
+		err  error
+	}{
+		{"add", tcBigIntCheckedAdd, []string{max256, "0"}, []uint64{u256}, max256, nil},
+		{"add overflow", tcBigIntCheckedAdd, []string{max256, "1"}, []uint64{u256}, "", ErrBigIntOverflow},
+		{"add unbounded", tcBigIntCheckedAdd, []string{max256, "1"}, []uint64{none}, "115792089237316195423570985008687907853269984665640564039457584007913129639936", nil},
+		{"sub underflow", tcBigIntCheckedSub, []string{"1", "2"}, []uint64{u256}, "", ErrBigIntOverflow},
+		{"sub signed", tcBigIntCheckedSub, []string{"1", "2"}, []uint64{i256}, "-1", nil},
+		{"mul overflow", tcBigIntCheckedMul, []string{max255, "2"}, []uint64{i256}, "", ErrBigIntOverflow},
+		{"div zero", tcBigIntCheckedDiv, []string{"1", "0"}, []uint64{u256}, "", ErrBigIntDivZero},
+		{"sdiv", tcBigIntCheckedDiv, []string{"-7", "2"}, []uint64{i256}, "-3", nil},
+		{"sdiv overflow", tcBigIntCheckedDiv, []string{min255, "-1"}, []uint64{i256}, "", ErrBigIntOverflow},
+		{"smod", tcBigIntCheckedMod, []string{"-7", "2"}, []uint64{i256}, "-1", nil},
+		{"mod", tcBigIntCheckedMod, []string{"-7", "2"}, []uint64{none}, "1", nil},
+		{"arg out of mode", tcBigIntCheckedAdd, []string{"-1", "1"}, []uint64{u256}, "", ErrBigIntOverflow},
+		{"invalid arg", tcBigIntCheckedAdd, []string{"1x", "1"}, []uint64{u256}, "", ErrBigIntInvalid},
+		{"invalid mode", tcBigIntCheckedAdd, []string{"1", "1"}, []uint64{3}, "", ErrBigIntMode},
+		{"pow", tcBigIntPow, []string{"2", "255"}, []uint64{u256}, "57896044618658097711785492504343953926634992332820282019728792003956564819968", nil},
+		{"pow overflow", tcBigIntPow, []string{"2", "256"}, []uint64{u256}, "", ErrBigIntOverflow},
+		{"pow signed overflow", tcBigIntPow, []string{"2", "255"}, []uint64{i256}, "", ErrBigIntOverflow},
+		{"pow negative", tcBigIntPow, []string{"-2", "3"}, []uint64{i256}, "-8", nil},
+		{"pow large", tcBigIntPow, []string{"3", "100000"}, []uint64{none}, "", ErrBigIntOverflow},
+		{"pow one", tcBigIntPow, []string{"-1", "0x" + strings.Repeat("f", 64)}, []uint64{u256}, "", ErrBigIntOverflow},
+		{"pow one signed", tcBigIntPow, []string{"-1", "0x" + strings.Repeat("f", 63)}, []uint64{i256}, "-1", nil},
+		{"modexp", tcBigIntModExp, []string{"4", "13", "497"}, []uint64{u256}, "445", nil},
+		{"modexp zero", tcBigIntModExp, []string{"4", "13", "0"}, []uint64{u256}, "", ErrBigIntDivZero},
+		{"sqrt", tcBigIntSqrt, []string{max256}, []uint64{u256}, "340282366920938463463374607431768211455", nil},
+		{"sqrt negative", tcBigIntSqrt, []string{"-4"}, []uint64{i256}, "", ErrBigIntInvalid},
+		{"abs", tcBigIntAbs, []string{"-5"}, []uint64{i256}, "5", nil},
+		{"abs overflow", tcBigIntAbs, []string{min255}, []uint64{i256}, "", ErrBigIntOverflow},
+		{"and", tcBigIntAnd, []string{"0xff", "-2"}, []uint64{i256}, "254", nil},
+		{"or", tcBigIntOr, []string{"0xf0", "0x0f"}, []uint64{u256}, "255", nil},
+		{"xor", tcBigIntXor, []string{"0xff", "0x0f"}, []uint64{u256}, "240", nil},
+		{"not", tcBigIntNot, []string{"0"}, []uint64{u256}, max256, nil},
+		{"not signed", tcBigIntNot, []string{"0"}, []uint64{i256}, "-1", nil},
+		{"shl wraps", tcBigIntShl, []string{max256}, []uint64{1, u256}, "115792089237316195423570985008687907853269984665640564039457584007913129639934", nil},
+		{"shl signed", tcBigIntShl, []string{"1"}, []uint64{255, i256}, min255, nil},
+		{"shl out", tcBigIntShl, []string{"1"}, []uint64{256, u256}, "0", nil},
+		{"shl unbounded", tcBigIntShl, []string{"1"}, []uint64{MaxBigIntBits, none}, "", ErrBigIntOverflow},
+		{"shl negative", tcBigIntShl, []string{"1"}, []uint64{0xffffffff, u256}, "", ErrBigIntInvalid},
+		{"sar", tcBigIntShr, []string{"-5"}, []uint64{1, i256}, "-3", nil},
+		{"shr", tcBigIntShr, []string{max256}, []uint64{255, u256}, "1", nil},
+		{"min", tcBigIntMin, []string{"-1", "1"}, []uint64{i256}, "-1", nil},
+		{"max", tcBigIntMax, []string{"-1", "1"}, []uint64{i256}, "1", nil},
+	}
+	for _, tt := range tests {
+		var args []uint64
+		for _, s := range tt.args {
+			args = append(args, cstr(s))
+		}
+		args = append(args, tt.ints...)
+		p, err := tt.fn(eng, -1, args)
+		if err != tt.err {
+			t.Fatalf("%s: err %v, want %v", tt.name, err, tt.err)
+		}
+		if err != nil {
+			continue
+		}
+		if s, _ := vmem.GetString(p); string(s) != tt.want {
+			t.Fatalf("%s: %s, want %s", tt.name, s, tt.want)
+		}
+	}
+
+	p, err := tcBigIntToBytes(eng, -1, []uint64{cstr("-2"), 32, i256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := vmem.GetBytes(p, 32)
+	if want := append(bytes.Repeat([]byte{0xff}, 31), 0xfe); !bytes.Equal(data, want) {
+		t.Fatalf("bytes %x", data)
+	}
+	p, err = tcBigIntFromBytes(eng, -1, []uint64{p, 32, i256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := vmem.GetString(p); string(s) != "-2" {
+		t.Fatalf("from bytes %s", s)
+	}
+	p, _ = tcBigIntToBytes(eng, -1, []uint64{cstr("0x1234"), 4, u256})
+	if data, _ := vmem.GetBytes(p, 4); !bytes.Equal(data, []byte{0, 0, 0x12, 0x34}) {
+		t.Fatalf("bytes %x", data)
+	}
+	p, _ = tcBigIntFromBytes(eng, -1, []uint64{p, 3, u256})
+	if s, _ := vmem.GetString(p); string(s) != "18" {
+		t.Fatalf("from bytes %s", s)
+	}
+	for _, tt := range []struct {
+		a    string
+		size uint64
+		mode uint64
+	}{{"256", 1, u256}, {"128", 1, i256}, {"-129", 1, i256}, {"-1", 32, u256}} {
+		if _, err := tcBigIntToBytes(eng, -1, []uint64{cstr(tt.a), tt.size, tt.mode}); err != ErrBigIntOverflow {
+			t.Fatalf("%s in %d bytes: %v", tt.a, tt.size, err)
+		}
+	}
+	if _, err := tcBigIntToBytes(eng, -1, []uint64{cstr("1"), 33, u256}); err != ErrInvalidApiArgs {
+		t.Fatalf("33 bytes: %v", err)
+	}
+}
+
+func TestBigIntExtGas(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+
+	small, err := gasBigIntPow(eng, -1, []uint64{cstr("2"), cstr("3"), bigIntModeUint256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := gasBigIntPow(eng, -1, []uint64{cstr("1"), cstr("0x" + strings.Repeat("f", 64)), bigIntModeUint256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if large-small != 31*(ExpByteGas+BigIntWordGas) {
+		t.Fatalf("pow gas %d, %d", small, large)
+	}
+	if _, err := gasBigIntCheckedAdd(eng, -1, []uint64{cstr("-1"), cstr("0"), bigIntModeUint256}); err != ErrBigIntOverflow {
+		t.Fatalf("gas of a failing call: %v", err)
+	}
+
+	// operands of 16 words: linear for sums, quadratic for products, and
+	// the copy of the result capped by MaxBigIntBits
+	one, wide := cstr("1"), cstr("0x"+strings.Repeat("f", MaxBigIntBits/4))
+	gas := func(fn func(*Engine, int64, []uint64) (uint64, error), a, b uint64) uint64 {
+		g, err := fn(eng, -1, []uint64{a, b, bigIntModeNone})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+	copyGas := func(bits int) uint64 { return ToWordSize(bigIntStringLen(bits)) * CopyGas }
+	if add := gas(gasBigIntCheckedAdd, wide, wide); add != GasExtStep*3+16*BigIntWordGas+copyGas(MaxBigIntBits+1) {
+		t.Fatalf("add gas %d", add)
+	}
+	if add := gas(gasBigIntCheckedAdd, one, one); add != GasExtStep*3+BigIntWordGas+copyGas(2) {
+		t.Fatalf("add gas %d", add)
+	}
+	if mul := gas(gasBigIntCheckedMul, wide, wide); mul != GasExtStep*3+256*BigIntWordGas+copyGas(MaxBigIntBits+1) {
+		t.Fatalf("mul gas %d", mul)
+	}
+	if div := gas(gasBigIntCheckedDiv, wide, one); div != GasExtStep*3+256*BigIntWordGas+copyGas(MaxBigIntBits+1) {
+		t.Fatalf("div gas %d", div)
+	}
+	if shl := gas(gasBigIntShl, one, 8); shl != GasExtStep*3+BigIntWordGas+copyGas(9) {
+		t.Fatalf("shl gas %d", shl)
+	}
+
+	m := cstr("0x" + strings.Repeat("f", 512))
+	modexp, err := gasBigIntModExp(eng, -1, []uint64{cstr("3"), m, m, bigIntModeNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if modexp < (256*256/4+96*256-3072)*2047/ModExpQuadCoeffDiv {
+		t.Fatalf("modexp gas %d", modexp)
+	}
+
+	for _, bits := range []int{0, 1, 8, 255, 256, MaxBigIntBits + 1} {
+		x := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		x.Neg(x.Sub(x, big.NewInt(1)))
+		if n := uint64(len(x.String())); n > bigIntStringLen(bits) {
+			t.Fatalf("%d bits: %d bytes, bound %d", bits, n, bigIntStringLen(bits))
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/deps/bn256"
+)
+
+func TestBn256(t *testing.T) {
+	eng, _ := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	input := func(data []byte) []uint64 {
+		p, err := vmem.SetBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []uint64{p, uint64(len(data))}
+	}
+	output := func(p uint64) []byte {
+		data, _ := vmem.GetBytes(p, 64)
+		return data
+	}
+
+	g1, _ := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002")
+	twice, _ := hex.DecodeString("030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4")
+	p, err := tcBn256Add(eng, -1, input(append(append([]byte{}, g1...), g1...)))
+	if err != nil || !bytes.Equal(output(p), twice) {
+		t.Fatalf("add %x, %v", output(p), err)
+	}
+	// missing bytes are zeros, the point at infinity
+	if p, err = tcBn256Add(eng, -1, input(g1)); err != nil || !bytes.Equal(output(p), g1) {
+		t.Fatalf("add infinity %x, %v", output(p), err)
+	}
+	scalar := make([]byte, 32)
+	scalar[31] = 2
+	if p, err = tcBn256ScalarMul(eng, -1, input(append(append([]byte{}, g1...), scalar...))); err != nil || !bytes.Equal(output(p), twice) {
+		t.Fatalf("mul %x, %v", output(p), err)
+	}
+	bad := append([]byte{}, g1...)
+	bad[63] = 3
+	if _, err := tcBn256Add(eng, -1, input(bad)); err != ErrBn256Point {
+		t.Fatalf("point out of the curve: %v", err)
+	}
+
+	// e(3·G1, 5·G2)·e(-15·G1, G2) = 1
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1)).Marshal()
+	var pairs []byte
+	pairs = append(pairs, new(bn256.G1).ScalarBaseMult(big.NewInt(3)).Marshal()...)
+	pairs = append(pairs, new(bn256.G2).ScalarBaseMult(big.NewInt(5)).Marshal()...)
+	pairs = append(pairs, new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(big.NewInt(15))).Marshal()...)
+	pairs = append(pairs, g2...)
+	if v, err := tcBn256Pairing(eng, -1, input(pairs)); err != nil || v != 1 {
+		t.Fatalf("pairing %d, %v", v, err)
+	}
+	if v, err := tcBn256Pairing(eng, -1, input(pairs[:192])); err != nil || v != 0 {
+		t.Fatalf("pairing %d, %v", v, err)
+	}
+	if _, err := tcBn256Pairing(eng, -1, input(pairs[:191])); err != ErrInvalidApiArgs {
+		t.Fatalf("pairing of 191 bytes: %v", err)
+	}
+	if gas, _ := gasBn256Pairing(eng, -1, input(pairs)); gas != Bn256PairingBaseGas+2*Bn256PairingPerPointGas {
+		t.Fatalf("pairing gas %d", gas)
+	}
+}
+
+// Points of the vectors below. Points of G2 are encoded as x imaginary, x
+// real, y imaginary, y real, as EIP-197 specifies.
+const (
+	bn256G1Gen   = "0000000000000000000000000000000000000000000000000000000000000001" + "0000000000000000000000000000000000000000000000000000000000000002"
+	bn256G2Gen   = "198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" + "1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" + "090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" + "12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"
+	bn256G2Neg   = "198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" + "1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" + "275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec" + "1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d"
+	bn256Zero    = "0000000000000000000000000000000000000000000000000000000000000000" + "0000000000000000000000000000000000000000000000000000000000000000"
+	bn256Chfast1 = "18b18acfb4c2c30276db5411368e7185b311dd124691610c5d3b74034e093dc9" + "063c909c4720840cb5134cb9f59fa749755796819658d32efc0d288198f37266"
+	bn256Chfast2 = "07c2b7f58a84bd6145f00c9c2bc0bb1a187f20ff2c92963a88019e7c6a014eed" + "06614e20c147e940f2d70da3f74c9a17df361706a4485c742bd6788478fa17d7"
+	bn256Chfast3 = "2243525c5efd4b9c3d3c45ac0ca3fe4dd85e830a4ce6b65fa1eeaee202839703" + "301d1d33be6da8e509df21cc35964723180eed7532537db9ae5e7d48f195c915"
+	bn256Chfast4 = "2bd3e6d0f3b142924f5ca7b49ce5b9d54c4703d7ae5648e61d02268b1a0a9fb7" + "21611ce0a6af85915e2f1d70300909ce2e49dfad4a4619c8390cae66cefdb204"
+	bn256Cdetrio = "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe3" + "1a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f6"
+)
+
+// Vectors of the precompiled contracts of EIP-196 and EIP-197. The chfast,
+// cdetrio and jeff ones are from the Ethereum tests, the others are built from
+// the generators and the field prime p.
+var (
+	bn256AddVectors = []struct{ name, input, want string }{
+		{"chfast1", bn256Chfast1 + bn256Chfast2, bn256Chfast3},
+		{"chfast2", bn256Chfast3 + bn256Chfast1, bn256Chfast4},
+		{"cdetrio1", "", bn256Zero},
+		{"cdetrio6", bn256G1Gen + bn256G1Gen, "030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd3" + "15ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4"},
+		{"cdetrio11", bn256G1Gen + bn256Zero, bn256G1Gen},
+	}
+	bn256ScalarMulVectors = []struct{ name, input, want string }{
+		{"chfast1", bn256Chfast4 + "00000000000000000000000000000000000000000000000011138ce750fa15c2", "070a8d6a982153cae4be29d434e8faef8a47b274a053f5a4ee2a6c9c13c31e5c" + "031b8ce914eba3a9ffb989f9cdd5b0f01943074bf4f0f315690ec3cec6981afc"},
+		{"cdetrio1 2^256-1", bn256Cdetrio + "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "2cde5879ba6f13c0b5aa4ef627f159a3347df9722efce88a9afbb20b763b4c41" + "1aa7e43076f6aee272755a7f9b84832e71559ba0d2e0b17d5f9f01755e5b0d11"},
+		{"cdetrio2 order-1", bn256Cdetrio + "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000", "1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe3" + "163511ddc1c3f25d396745388200081287b3fd1472d8339d5fecb2eae0830451"},
+		{"cdetrio3 2^128", bn256Cdetrio + "0000000000000000000000000000000100000000000000000000000000000000", "1051acb0700ec6d42a88215852d582efbaef31529b6fcbc3277b5c1b300f5cf0" + "135b2394bb45ab04b8bd7611bd2dfe1de6a4e6e2ccea1ea1955f577cd66af85b"},
+		{"order", bn256Cdetrio + "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001", bn256Zero},
+		{"zero", bn256Cdetrio + "0000000000000000000000000000000000000000000000000000000000000000", bn256Zero},
+		{"no scalar", bn256Cdetrio, bn256Zero},
+	}
+	bn256PairingVectors = []struct {
+		name, input string
+		want        uint64
+	}{
+		{"jeff1", "1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f59" + "3034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41" +
+			"209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf7" + "04bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a41678" +
+			"2bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d" + "120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550" +
+			"111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c" + "2032c61a830e3c17286de9462bf242fca2883585b93870a73853face6a6bf411" +
+			bn256G2Gen, 1},
+		{"one_point", bn256G1Gen + bn256G2Gen, 0},
+		{"two_point_match", bn256G1Gen + bn256G2Gen + bn256G1Gen + bn256G2Neg, 1},
+		{"two_point_fail", bn256G1Gen + bn256G2Gen + bn256G1Gen + bn256G2Gen, 0},
+		{"infinity", bn256Zero + bn256G2Gen, 1},
+	}
+	bn256InvalidG1 = []struct{ name, point string }{
+		{"off curve", "0000000000000000000000000000000000000000000000000000000000000001" + "0000000000000000000000000000000000000000000000000000000000000003"},
+		{"y not below p", "0000000000000000000000000000000000000000000000000000000000000001" + "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd49"},
+		{"x is p", "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47" + "0000000000000000000000000000000000000000000000000000000000000000"},
+	}
+	bn256InvalidG2 = []struct{ name, point string }{
+		{"real parts first", "1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" + "198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" + "12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa" + "090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b"},
+		{"x imaginary not below p", "49f2e206733ee8642ab1056db37cb583892bb3c49e1bb19fd40511ce87701009" + "1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" + "090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" + "12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"},
+		{"not in G2", "0000000000000000000000000000000000000000000000000000000000000000" + "0000000000000000000000000000000000000000000000000000000000000001" + "0d1271953ed9ea0836846e70a1934187998c7f790cb4d7511b7f8da82de048a4" + "2869111d5381f072f8e2728fdb825a51aadd70e52c9830e9ab4b871c0531f1bb"},
+	}
+)
+
+func TestBn256Vectors(t *testing.T) {
+	eng, _ := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	input := func(s string) []uint64 {
+		data, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := vmem.SetBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []uint64{p, uint64(len(data))}
+	}
+	output := func(p uint64) string {
+		data, _ := vmem.GetBytes(p, 64)
+		return hex.EncodeToString(data)
+	}
+
+	for _, v := range bn256AddVectors {
+		if p, err := tcBn256Add(eng, -1, input(v.input)); err != nil || output(p) != v.want {
+			t.Errorf("add %s: %s, %v", v.name, output(p), err)
+		}
+	}
+	for _, v := range bn256ScalarMulVectors {
+		if p, err := tcBn256ScalarMul(eng, -1, input(v.input)); err != nil || output(p) != v.want {
+			t.Errorf("mul %s: %s, %v", v.name, output(p), err)
+		}
+	}
+	for _, v := range bn256PairingVectors {
+		if ret, err := tcBn256Pairing(eng, -1, input(v.input)); err != nil || ret != v.want {
+			t.Errorf("pairing %s: %d, %v", v.name, ret, err)
+		}
+	}
+	if g2 := hex.EncodeToString(new(bn256.G2).ScalarBaseMult(big.NewInt(1)).Marshal()); g2 != bn256G2Gen {
+		t.Errorf("G2 generator marshals to %s", g2)
+	}
+
+	for _, v := range bn256InvalidG1 {
+		if _, err := tcBn256Add(eng, -1, input(bn256G1Gen+v.point)); err != ErrBn256Point {
+			t.Errorf("add %s: %v", v.name, err)
+		}
+		if _, err := tcBn256ScalarMul(eng, -1, input(v.point+"0000000000000000000000000000000000000000000000000000000000000001")); err != ErrBn256Point {
+			t.Errorf("mul %s: %v", v.name, err)
+		}
+		if _, err := tcBn256Pairing(eng, -1, input(v.point+bn256G2Gen)); err != ErrBn256Point {
+			t.Errorf("pairing %s: %v", v.name, err)
+		}
+	}
+	for _, v := range bn256InvalidG2 {
+		if _, err := tcBn256Pairing(eng, -1, input(bn256G1Gen+v.point)); err != ErrBn256Point {
+			t.Errorf("pairing %s: %v", v.name, err)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package vm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Coverage records which functions and basic blocks of contracts run on the
+// interpreter, per contract code hash, across any number of Engine.Run
+// calls. Engines with a Coverage set bypass natively compiled code.
+type Coverage struct {
+	lock      sync.Mutex
+	contracts map[[32]byte]*contractCoverage
+}
+
+type contractCoverage struct {
+	app   *APP
+	funcs map[int64]*funcCoverage
+}
+
+type funcCoverage struct {
+	calls  uint64
+	blocks []uint64
+}
+
+// FuncCoverage is the coverage of one wasm function.
+type FuncCoverage struct {
+	CodeHash string
+	App      string
+	Func     string
+	Index    int64
+	Calls    uint64
+	Blocks   int
+	Covered  int
+}
+
+func NewCoverage() *Coverage {
+	return &Coverage{
+		contracts: make(map[[32]byte]*contractCoverage),
+	}
+}
+
+// SetCoverage starts collecting coverage into c; nil stops it.
+func (eng *Engine) SetCoverage(c *Coverage) {
+	eng.coverage = c
+}
+
+// step is called before every interpreter instruction of app.
+func (c *Coverage) step(app *APP) {
+	if app == nil {
+		return
+	}
+	fnIndex, pc, ok := app.interpFrame()
+	if !ok {
+		return
+	}
+	fm := app.dbg.funcMap(app, fnIndex)
+	if fm == nil || len(fm.pcs) == 0 {
+		return
+	}
+	i := fm.lookup(pc)
+	if pc != 0 && (fm.pcs[i] != pc || !fm.leader[i]) {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cc, ok := c.contracts[app.codeHash]
+	if !ok {
+		cc = &contractCoverage{app: app, funcs: make(map[int64]*funcCoverage)}
+		c.contracts[app.codeHash] = cc
+	}
+	fc, ok := cc.funcs[fnIndex]
+	if !ok {
+		fc = &funcCoverage{blocks: make([]uint64, fm.numBlocks())}
+		cc.funcs[fnIndex] = fc
+	}
+	if app.VM.FrameEntry() {
+		fc.calls++
+	}
+	fc.blocks[fm.blocks[i]]++
+}
+
+func (c *Coverage) sortedContracts() []*contractCoverage {
+	list := make([]*contractCoverage, 0, len(c.contracts))
+	for _, cc := range c.contracts {
+		list = append(list, cc)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].app.String() < list[j].app.String()
+	})
+	return list
+}
+
+// Summary returns the coverage of every function of every contract seen,
+// including the functions that never ran.
+func (c *Coverage) Summary() []FuncCoverage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var list []FuncCoverage
+	for _, cc := range c.sortedContracts() {
+		app := cc.app
+		for i, fn := range app.Module.FunctionIndexSpace {
+			if fn.IsHost() {
+				continue
+			}
+			fnIndex := int64(i)
+			fm := app.dbg.funcMap(app, fnIndex)
+			if fm == nil {
+				continue
+			}
+
+			fcov := FuncCoverage{
+				CodeHash: hex.EncodeToString(app.codeHash[:]),
+				App:      app.Name,
+				Func:     app.funcName(fnIndex),
+				Index:    fnIndex,
+				Blocks:   fm.numBlocks(),
+			}
+			if fc, ok := cc.funcs[fnIndex]; ok {
+				fcov.Calls = fc.calls
+				for _, n := range fc.blocks {
+					if n > 0 {
+						fcov.Covered++
+					}
+				}
+			}
+			list = append(list, fcov)
+		}
+	}
+	return list
+}
+
+// WriteSummary writes the per function coverage as a text table.
+func (c *Coverage) WriteSummary(w io.Writer) error {
+	hash := ""
+	for _, f := range c.Summary() {
+		if f.CodeHash != hash {
+			hash = f.CodeHash
+			if _, err := fmt.Fprintf(w, "contract %s (%s)\n", f.CodeHash, f.App); err != nil {
+				return err
+			}
+		}
+		percent := 100.0
+		if f.Blocks > 0 {
+			percent = float64(f.Covered) * 100 / float64(f.Blocks)
+		}
+		if _, err := fmt.Fprintf(w, "  %-32s calls %-8d blocks %d/%d %6.1f%%\n",
+			f.Func, f.Calls, f.Covered, f.Blocks, percent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type lcovFile struct {
+	lines map[int]uint64
+	funcs []lcovFunc
+}
+
+type lcovFunc struct {
+	name  string
+	line  int
+	calls uint64
+}
+
+// WriteLcov writes line coverage in lcov tracefile format for the contracts
+// that carry DWARF line information. It returns false if there was none.
+func (c *Coverage) WriteLcov(w io.Writer) (bool, error) {
+	c.lock.Lock()
+	files := make(map[string]*lcovFile)
+	for _, cc := range c.sortedContracts() {
+		app := cc.app
+		if !app.HasSourceMap() {
+			continue
+		}
+		for i, fn := range app.Module.FunctionIndexSpace {
+			if fn.IsHost() {
+				continue
+			}
+			fnIndex := int64(i)
+			fm := app.dbg.funcMap(app, fnIndex)
+			if fm == nil {
+				continue
+			}
+			fc := cc.funcs[fnIndex]
+
+			first := true
+			for j, pc := range fm.pcs {
+				line, ok := app.SourceLine(fnIndex, pc)
+				if !ok {
+					continue
+				}
+				f, ok := files[line.File]
+				if !ok {
+					f = &lcovFile{lines: make(map[int]uint64)}
+					files[line.File] = f
+				}
+				var count uint64
+				if fc != nil {
+					count = fc.blocks[fm.blocks[j]]
+				}
+				if cur, ok := f.lines[line.Line]; !ok || count > cur {
+					f.lines[line.Line] = count
+				}
+				if first {
+					first = false
+					lf := lcovFunc{name: app.funcName(fnIndex), line: line.Line}
+					if fc != nil {
+						lf.calls = fc.calls
+					}
+					f.funcs = append(f.funcs, lf)
+				}
+			}
+		}
+	}
+	c.lock.Unlock()
+
+	if len(files) == 0 {
+		return false, nil
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := files[name]
+		if _, err := fmt.Fprintf(w, "TN:\nSF:%s\n", name); err != nil {
+			return true, err
+		}
+		hit := 0
+		for _, fn := range f.funcs {
+			fmt.Fprintf(w, "FN:%d,%s\n", fn.line, fn.name)
+		}
+		for _, fn := range f.funcs {
+			fmt.Fprintf(w, "FNDA:%d,%s\n", fn.calls, fn.name)
+			if fn.calls > 0 {
+				hit++
+			}
+		}
+		fmt.Fprintf(w, "FNF:%d\nFNH:%d\n", len(f.funcs), hit)
+
+		lines := make([]int, 0, len(f.lines))
+		for l := range f.lines {
+			lines = append(lines, l)
+		}
+		sort.Ints(lines)
+		hit = 0
+		for _, l := range lines {
+			fmt.Fprintf(w, "DA:%d,%d\n", l, f.lines[l])
+			if f.lines[l] > 0 {
+				hit++
+			}
+		}
+		if _, err := fmt.Fprintf(w, "LF:%d\nLH:%d\nend_of_record\n", len(lines), hit); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,22 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestCoverageLoopAtEntry(t *testing.T) {
+	eng, app := loopTestApp(t)
+	c := NewCoverage()
+	eng.SetCoverage(c)
+
+	if _, err := app.VM.ExecCode(1); err != nil {
+		t.Fatal(err)
+	}
+	calls := make(map[int64]uint64)
+	for _, f := range c.Summary() {
+		calls[f.Index] = f.Calls
+	}
+	if calls[0] != 2 || calls[1] != 1 {
+		t.Fatalf("got calls %v, want 2 of func 0 and 1 of func 1", calls)
+	}
+}
@@ -0,0 +1,209 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type DebugEventKind int
+
+const (
+	// DebugStep is raised before an interpreter instruction executes.
+	DebugStep DebugEventKind = iota
+	// DebugHostCall is raised before a host function is called.
+	DebugHostCall
+)
+
+type DebugAction int
+
+const (
+	DebugContinue DebugAction = iota // run until the next breakpoint
+	DebugStepInto                    // stop again before the next instruction
+)
+
+// DebugEvent describes where a Debugger stopped the contract.
+type DebugEvent struct {
+	Kind  DebugEventKind
+	Eng   *Engine
+	App   *APP
+	Frame *Frame
+	Op    string // interpreter opcode, for DebugStep
+	Where string
+	Src   *SourceLine
+
+	Host string // host function name, for DebugHostCall
+	Args []uint64
+}
+
+func (ev *DebugEvent) String() string {
+	where := ev.Where
+	if ev.Src != nil {
+		where = fmt.Sprintf("%s (%s)", where, ev.Src)
+	}
+	if ev.Kind == DebugHostCall {
+		return fmt.Sprintf("%s: host call %s%v at %s", ev.App.Name, ev.Host, ev.Args, where)
+	}
+	return fmt.Sprintf("%s: %s at %s", ev.App.Name, ev.Op, where)
+}
+
+// Debugger stops interpreter execution on breakpoints or single steps and
+// hands control to Handler, which runs on the contract goroutine and decides
+// how to resume. Natively compiled code is bypassed while a Debugger is set.
+type Debugger struct {
+	Handler func(ev *DebugEvent) DebugAction
+
+	lock       sync.Mutex
+	funcBreaks map[string]bool
+	hostBreaks map[string]bool
+	step       bool
+}
+
+// NewDebugger returns a Debugger that stops before the first instruction.
+func NewDebugger(handler func(ev *DebugEvent) DebugAction) *Debugger {
+	return &Debugger{
+		Handler:    handler,
+		funcBreaks: make(map[string]bool),
+		hostBreaks: make(map[string]bool),
+		step:       true,
+	}
+}
+
+// SetDebugger attaches d to eng; nil detaches it.
+func (eng *Engine) SetDebugger(d *Debugger) {
+	eng.debugger = d
+}
+
+// BreakFunc stops on entry of the wasm function with the given name or
+// function index.
+func (d *Debugger) BreakFunc(name string) {
+	d.lock.Lock()
+	d.funcBreaks[name] = true
+	d.lock.Unlock()
+}
+
+// BreakHost stops before every call to the named host function,
+// e.g. TC_StorageSet.
+func (d *Debugger) BreakHost(name string) {
+	d.lock.Lock()
+	d.hostBreaks[name] = true
+	d.lock.Unlock()
+}
+
+// Clear removes a function or host breakpoint.
+func (d *Debugger) Clear(name string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, f := d.funcBreaks[name]
+	_, h := d.hostBreaks[name]
+	delete(d.funcBreaks, name)
+	delete(d.hostBreaks, name)
+	return f || h
+}
+
+// Breakpoints lists the breakpoints as "func NAME" and "host NAME".
+func (d *Debugger) Breakpoints() []string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var list []string
+	for name := range d.funcBreaks {
+		list = append(list, "func "+name)
+	}
+	for name := range d.hostBreaks {
+		list = append(list, "host "+name)
+	}
+	return list
+}
+
+func (d *Debugger) hitFunc(app *APP, fnIndex int64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(d.funcBreaks) == 0 {
+		return false
+	}
+	return d.funcBreaks[fmt.Sprintf("%d", fnIndex)] || d.funcBreaks[app.funcName(fnIndex)]
+}
+
+func (d *Debugger) hitHost(name string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.hostBreaks[name]
+}
+
+// trace receives the Backend.Trace calls of the interpreter.
+func (d *Debugger) trace(eng *Engine, msg string, v []interface{}) {
+	app := eng.runningFrame
+	if app == nil || d.Handler == nil {
+		return
+	}
+
+	var ev *DebugEvent
+	switch {
+	case strings.HasPrefix(msg, "pc:"):
+		fnIndex, pc, ok := app.interpFrame()
+		if !ok {
+			return
+		}
+		if !d.step && !(app.VM.FrameEntry() && d.hitFunc(app, fnIndex)) {
+			return
+		}
+		ev = &DebugEvent{Kind: DebugStep, Op: app.opName(fnIndex, pc)}
+	case msg == "host function call begin":
+		index, args := traceHostCall(v)
+		if index < 0 || int(index) >= len(app.Module.FunctionIndexSpace) {
+			return
+		}
+		name := app.Module.FunctionIndexSpace[index].Name
+		if !d.hitHost(name) {
+			return
+		}
+		ev = &DebugEvent{Kind: DebugHostCall, Host: name, Args: args}
+	default:
+		return
+	}
+
+	ev.Eng = eng
+	ev.App = app
+	ev.Frame = app.CurrentFrame()
+	if ev.Frame != nil {
+		pc := ev.Frame.PC
+		if ev.Kind == DebugHostCall {
+			pc = instrPC(pc)
+		}
+		ev.Where, ev.Src = app.locate(ev.Frame.Func, pc)
+	}
+
+	action := d.Handler(ev)
+	d.lock.Lock()
+	d.step = action == DebugStepInto
+	d.lock.Unlock()
+}
+
+// traceHostCall decodes the "index", i, "args", args key/values of a host
+// call trace.
+func traceHostCall(v []interface{}) (int64, []uint64) {
+	index := int64(-1)
+	var args []uint64
+	for i := 0; i+1 < len(v); i += 2 {
+		switch v[i] {
+		case "index":
+			index, _ = v[i+1].(int64)
+		case "args":
+			args, _ = v[i+1].([]uint64)
+		}
+	}
+	return index, args
+}
+
+// CallStack returns the contract frames from the outermost caller to the
+// running app.
+func (eng *Engine) CallStack() []*APP {
+	var frames []*APP
+	for i := 0; i <= eng.FrameIndex; i++ {
+		frames = append(frames, eng.AppFrames[i])
+	}
+	if eng.runningFrame != nil {
+		frames = append(frames, eng.runningFrame)
+	}
+	return frames
+}
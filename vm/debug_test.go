@@ -0,0 +1,76 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/state"
+	"github.com/xunleichain/tc-wasm/mock/types"
+)
+
+// loopTestModule returns a module whose function 0 starts with a loop that
+// branches back to its first instruction until its argument drops to zero,
+// and whose function 1 calls it with 3 then 2.
+func loopTestModule() []byte {
+	section := func(id byte, payload ...byte) []byte {
+		return append([]byte{id, byte(len(payload))}, payload...)
+	}
+	loop := []byte{0, 0x03, 0x40, 0x20, 0, 0x41, 1, 0x6b, 0x22, 0, 0x0d, 0, 0x0b, 0x20, 0, 0x0b}
+	main := []byte{0, 0x41, 3, 0x10, 0, 0x1a, 0x41, 2, 0x10, 0, 0x0b}
+	code := append([]byte{2, byte(len(loop))}, loop...)
+	code = append(append(code, byte(len(main))), main...)
+
+	var m bytes.Buffer
+	m.Write([]byte{0x00, 0x61, 0x73, 0x6d, 1, 0, 0, 0})
+	m.Write(section(1, 2, 0x60, 1, 0x7f, 1, 0x7f, 0x60, 0, 1, 0x7f))
+	m.Write(section(3, 2, 0, 1))
+	m.Write(section(5, 1, 0, 1))
+	m.Write(section(6, 1, 0x7f, 0, 0x41, 0x80, 0xa0, 0x01, 0x0b))
+	exports := []byte{3, 11}
+	exports = append(exports, "__heap_base"...)
+	exports = append(exports, 3, 0, 4)
+	exports = append(exports, "loop"...)
+	exports = append(exports, 0, 0, 4)
+	exports = append(exports, "main"...)
+	exports = append(exports, 0, 1)
+	m.Write(section(7, exports...))
+	m.Write(section(10, code...))
+	return m.Bytes()
+}
+
+func loopTestApp(t *testing.T) (*Engine, *APP) {
+	db, _ := state.New()
+	addr := types.BytesToAddress([]byte{3})
+	contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+	eng := NewEngine(contract, 1000000, db, log.Test())
+	app, err := eng.NewApp(addr.String(), loopTestModule(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eng.runningFrame = app
+	return eng, app
+}
+
+func TestDebugFuncBreakAtLoop(t *testing.T) {
+	eng, app := loopTestApp(t)
+	stops := 0
+	dbg := NewDebugger(func(ev *DebugEvent) DebugAction {
+		if ev.Frame == nil || ev.Frame.PC != 0 {
+			t.Errorf("stopped at %s", ev)
+		} else if ev.Frame.Func == 0 {
+			stops++
+		}
+		return DebugContinue
+	})
+	dbg.BreakFunc("0")
+	eng.SetDebugger(dbg)
+
+	if _, err := app.VM.ExecCode(1); err != nil {
+		t.Fatal(err)
+	}
+	if stops != 2 {
+		t.Fatalf("function breakpoint hit %d times, want 2", stops)
+	}
+}
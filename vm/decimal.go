@@ -0,0 +1,378 @@
+package vm
+
+import (
+	"math/big"
+	"strings"
+)
+
+// The TC_Decimal api computes on fixed-point decimal strings, as "-12.345",
+// exactly: the results are then rounded to the scale given by the call, the
+// number of digits after the point, with one of the rounding modes below.
+// Operands and results are limited to MaxDecimalDigits digits, and scales to
+// MaxDecimalScale.
+
+const (
+	decimalRoundHalfEven = 0 // to the nearest, ties to even
+	decimalRoundDown     = 1 // toward zero
+	decimalRoundUp       = 2 // away from zero
+)
+
+var bigInt10 = big.NewInt(10)
+
+// decimal is the value m * 10^-exp.
+type decimal struct {
+	m   *big.Int
+	exp int
+}
+
+func parseDecimal(s string) (decimal, error) {
+	if len(s) == 0 || len(s) > MaxDecimalDigits+2 {
+		return decimal{}, ErrDecimalInvalid
+	}
+	digits := s
+	if digits[0] == '-' || digits[0] == '+' {
+		digits = digits[1:]
+	}
+	exp := 0
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		exp = len(digits) - i - 1
+		digits = digits[:i] + digits[i+1:]
+		if i == 0 || exp == 0 {
+			return decimal{}, ErrDecimalInvalid
+		}
+	}
+	if len(digits) == 0 || strings.Trim(digits, "0123456789") != "" {
+		return decimal{}, ErrDecimalInvalid
+	}
+	m, _ := new(big.Int).SetString(digits, 10)
+	if s[0] == '-' {
+		m.Neg(m)
+	}
+	return decimal{m, exp}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigInt10, big.NewInt(int64(n)), nil)
+}
+
+// decimalDiv returns n / d rounded to an integer with mode.
+func decimalDiv(n, d *big.Int, mode int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if r.Sign() == 0 || mode == decimalRoundDown {
+		return q
+	}
+	away := mode == decimalRoundUp
+	if mode == decimalRoundHalfEven {
+		r2 := new(big.Int).Abs(r)
+		c := r2.Lsh(r2, 1).CmpAbs(d)
+		away = c > 0 || (c == 0 && q.Bit(0) == 1)
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(n.Sign()*d.Sign())))
+	}
+	return q
+}
+
+// rescale returns x rounded to scale digits after the point.
+func (x decimal) rescale(scale int, mode int) decimal {
+	if x.exp <= scale {
+		return decimal{new(big.Int).Mul(x.m, pow10(scale-x.exp)), scale}
+	}
+	return decimal{decimalDiv(x.m, pow10(x.exp-scale), mode), scale}
+}
+
+func (x decimal) String() string {
+	s := new(big.Int).Abs(x.m).String()
+	if x.exp > 0 {
+		if len(s) <= x.exp {
+			s = strings.Repeat("0", x.exp-len(s)+1) + s
+		}
+		s = s[:len(s)-x.exp] + "." + s[len(s)-x.exp:]
+	}
+	if x.m.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+func decimalScale(arg uint64) (int, error) {
+	scale := int(int32(arg))
+	if scale < 0 || scale > MaxDecimalScale {
+		return 0, ErrDecimalScale
+	}
+	return scale, nil
+}
+
+func decimalRounding(arg uint64) (int, error) {
+	mode := int(int32(arg))
+	if mode < decimalRoundHalfEven || mode > decimalRoundUp {
+		return 0, ErrDecimalRounding
+	}
+	return mode, nil
+}
+
+// decimalArgs reads the decimals at ptrs.
+func decimalArgs(eng *Engine, ptrs ...uint64) ([]decimal, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	xs := make([]decimal, len(ptrs))
+	for i, ptr := range ptrs {
+		s, err := vmem.GetString(ptr)
+		if err != nil {
+			return nil, ErrMemoryGet
+		}
+		if xs[i], err = parseDecimal(string(s)); err != nil {
+			return nil, err
+		}
+	}
+	return xs, nil
+}
+
+// setDecimal returns x as a string in the memory of the running frame.
+func setDecimal(eng *Engine, x decimal) (uint64, error) {
+	s := x.String()
+	if len(strings.TrimLeft(s, "-.0")) > MaxDecimalDigits {
+		return 0, ErrDecimalOverflow
+	}
+	app, _ := eng.RunningAppFrame()
+	ptr, err := app.VM.VMemory().SetBytes([]byte(s))
+	if err != nil {
+		return 0, ErrMemorySet
+	}
+	return ptr, nil
+}
+
+// tcDecimalOp computes a op b, with the scale and rounding of args[2:].
+func tcDecimalOp(eng *Engine, args []uint64, op bigIntOpType) (uint64, error) {
+	scale, err := decimalScale(args[2])
+	if err != nil {
+		return 0, err
+	}
+	mode, err := decimalRounding(args[3])
+	if err != nil {
+		return 0, err
+	}
+	xs, err := decimalArgs(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	a, b := xs[0], xs[1]
+
+	var x decimal
+	switch op {
+	case bigIntOpAdd, bigIntOpSub:
+		exp := a.exp
+		if b.exp > exp {
+			exp = b.exp
+		}
+		a, b = a.rescale(exp, mode), b.rescale(exp, mode)
+		if op == bigIntOpAdd {
+			x = decimal{a.m.Add(a.m, b.m), exp}
+		} else {
+			x = decimal{a.m.Sub(a.m, b.m), exp}
+		}
+	case bigIntOpMul:
+		x = decimal{a.m.Mul(a.m, b.m), a.exp + b.exp}
+	case bigIntOpDiv:
+		if b.m.Sign() == 0 {
+			return 0, ErrDecimalDivZero
+		}
+		// a/b = a.m*10^(scale+b.exp-a.exp) / b.m at scale
+		n, d := a.m, b.m
+		if shift := scale + b.exp - a.exp; shift >= 0 {
+			n = n.Mul(n, pow10(shift))
+		} else {
+			d = new(big.Int).Mul(d, pow10(-shift))
+		}
+		return setDecimal(eng, decimal{decimalDiv(n, d, mode), scale})
+	}
+	return setDecimal(eng, x.rescale(scale, mode))
+}
+
+type TCDecimalAdd struct{}
+
+func (t *TCDecimalAdd) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalAdd(eng, index, args)
+}
+func (t *TCDecimalAdd) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalAdd(eng, index, args)
+}
+
+// c: char *TC_DecimalAdd(char *a, char *b, int scale, int rounding)
+func tcDecimalAdd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcDecimalOp(eng, args, bigIntOpAdd)
+}
+
+type TCDecimalSub struct{}
+
+func (t *TCDecimalSub) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalSub(eng, index, args)
+}
+func (t *TCDecimalSub) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalSub(eng, index, args)
+}
+
+// c: char *TC_DecimalSub(char *a, char *b, int scale, int rounding)
+func tcDecimalSub(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcDecimalOp(eng, args, bigIntOpSub)
+}
+
+type TCDecimalMul struct{}
+
+func (t *TCDecimalMul) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalMul(eng, index, args)
+}
+func (t *TCDecimalMul) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalMul(eng, index, args)
+}
+
+// c: char *TC_DecimalMul(char *a, char *b, int scale, int rounding)
+func tcDecimalMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcDecimalOp(eng, args, bigIntOpMul)
+}
+
+type TCDecimalDiv struct{}
+
+func (t *TCDecimalDiv) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalDiv(eng, index, args)
+}
+func (t *TCDecimalDiv) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalDiv(eng, index, args)
+}
+
+// c: char *TC_DecimalDiv(char *a, char *b, int scale, int rounding)
+func tcDecimalDiv(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return tcDecimalOp(eng, args, bigIntOpDiv)
+}
+
+type TCDecimalCmp struct{}
+
+func (t *TCDecimalCmp) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalCmp(eng, index, args)
+}
+func (t *TCDecimalCmp) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalCmp(eng, index, args)
+}
+
+// c: int TC_DecimalCmp(char *a, char *b)
+func tcDecimalCmp(eng *Engine, index int64, args []uint64) (uint64, error) {
+	xs, err := decimalArgs(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	a, b := xs[0], xs[1]
+	if a.exp < b.exp {
+		a = a.rescale(b.exp, decimalRoundDown)
+	} else {
+		b = b.rescale(a.exp, decimalRoundDown)
+	}
+	return uint64(a.m.Cmp(b.m)), nil
+}
+
+type TCDecimalRescale struct{}
+
+func (t *TCDecimalRescale) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalRescale(eng, index, args)
+}
+func (t *TCDecimalRescale) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalRescale(eng, index, args)
+}
+
+// c: char *TC_DecimalRescale(char *a, int scale, int rounding)
+func tcDecimalRescale(eng *Engine, index int64, args []uint64) (uint64, error) {
+	scale, err := decimalScale(args[1])
+	if err != nil {
+		return 0, err
+	}
+	mode, err := decimalRounding(args[2])
+	if err != nil {
+		return 0, err
+	}
+	xs, err := decimalArgs(eng, args[0])
+	if err != nil {
+		return 0, err
+	}
+	return setDecimal(eng, xs[0].rescale(scale, mode))
+}
+
+type TCDecimalFormat struct{}
+
+func (t *TCDecimalFormat) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalFormat(eng, index, args)
+}
+func (t *TCDecimalFormat) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalFormat(eng, index, args)
+}
+
+// TC_DecimalFormat returns the decimal string of an integer amount of units
+// of 10^-decimals, as a token balance, without trailing zeros.
+// c: char *TC_DecimalFormat(char *units, int decimals)
+func tcDecimalFormat(eng *Engine, index int64, args []uint64) (uint64, error) {
+	decimals, err := decimalScale(args[1])
+	if err != nil {
+		return 0, err
+	}
+	xs, err := decimalArgs(eng, args[0])
+	if err != nil {
+		return 0, err
+	}
+	if xs[0].exp != 0 {
+		return 0, ErrDecimalInvalid
+	}
+
+	x := decimal{xs[0].m, decimals}
+	for x.exp > 0 {
+		q, r := new(big.Int).QuoRem(x.m, bigInt10, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		x = decimal{q, x.exp - 1}
+	}
+	return setDecimal(eng, x)
+}
+
+type TCDecimalToUnits struct{}
+
+func (t *TCDecimalToUnits) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcDecimalToUnits(eng, index, args)
+}
+func (t *TCDecimalToUnits) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasDecimalToUnits(eng, index, args)
+}
+
+// TC_DecimalToUnits is the reverse of TC_DecimalFormat, rounding a to an
+// integer amount of units of 10^-decimals.
+// c: char *TC_DecimalToUnits(char *a, int decimals, int rounding)
+func tcDecimalToUnits(eng *Engine, index int64, args []uint64) (uint64, error) {
+	decimals, err := decimalScale(args[1])
+	if err != nil {
+		return 0, err
+	}
+	mode, err := decimalRounding(args[2])
+	if err != nil {
+		return 0, err
+	}
+	xs, err := decimalArgs(eng, args[0])
+	if err != nil {
+		return 0, err
+	}
+	x := xs[0].rescale(decimals, mode)
+	return setDecimal(eng, decimal{x.m, 0})
+}
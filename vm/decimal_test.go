@@ -0,0 +1,95 @@
+package vm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+
+	const (
+		even = decimalRoundHalfEven
+		down = decimalRoundDown
+		up   = decimalRoundUp
+	)
+	type call func(*Engine, int64, []uint64) (uint64, error)
+	tests := []struct {
+		name string
+		fn   call
+		args []string // decimals, then the int args
+		ints []uint64
+		want string
+		err  error
+	}{
+		{"add", tcDecimalAdd, []string{"1.25", "-0.5"}, []uint64{2, even}, "0.75", nil},
+		{"add scale", tcDecimalAdd, []string{"1", "0.5"}, []uint64{3, even}, "1.500", nil},
+		{"sub", tcDecimalSub, []string{"0.1", "0.3"}, []uint64{1, even}, "-0.2", nil},
+		{"mul", tcDecimalMul, []string{"1.5", "1.5"}, []uint64{2, even}, "2.25", nil},
+		{"mul even", tcDecimalMul, []string{"1.5", "1.5"}, []uint64{1, even}, "2.2", nil},
+		{"mul even up", tcDecimalMul, []string{"1.5", "2.5"}, []uint64{1, even}, "3.8", nil},
+		{"mul down", tcDecimalMul, []string{"-1.5", "1.5"}, []uint64{1, down}, "-2.2", nil},
+		{"mul up", tcDecimalMul, []string{"-1.5", "1.5"}, []uint64{1, up}, "-2.3", nil},
+		{"div", tcDecimalDiv, []string{"1", "3"}, []uint64{4, even}, "0.3333", nil},
+		{"div up", tcDecimalDiv, []string{"1", "3"}, []uint64{4, up}, "0.3334", nil},
+		{"div negative", tcDecimalDiv, []string{"-2", "3"}, []uint64{2, even}, "-0.67", nil},
+		{"div scales", tcDecimalDiv, []string{"0.001", "0.25"}, []uint64{0, up}, "1", nil},
+		{"div large", tcDecimalDiv, []string{"100", "0.00001"}, []uint64{0, even}, "10000000", nil},
+		{"div zero", tcDecimalDiv, []string{"1", "0.0"}, []uint64{2, even}, "", ErrDecimalDivZero},
+		{"rescale", tcDecimalRescale, []string{"2.345"}, []uint64{2, even}, "2.34", nil},
+		{"rescale up", tcDecimalRescale, []string{"2.341"}, []uint64{2, up}, "2.35", nil},
+		{"rescale small", tcDecimalRescale, []string{"-0.004"}, []uint64{2, up}, "-0.01", nil},
+		{"rescale zero", tcDecimalRescale, []string{"-0.004"}, []uint64{2, down}, "0.00", nil},
+		{"format", tcDecimalFormat, []string{"1500000000000000000"}, []uint64{18}, "1.5", nil},
+		{"format small", tcDecimalFormat, []string{"-5"}, []uint64{3}, "-0.005", nil},
+		{"format integer", tcDecimalFormat, []string{"1000"}, []uint64{3}, "1", nil},
+		{"format decimal", tcDecimalFormat, []string{"1.5"}, []uint64{3}, "", ErrDecimalInvalid},
+		{"units", tcDecimalToUnits, []string{"1.5"}, []uint64{18, even}, "1500000000000000000", nil},
+		{"units rounded", tcDecimalToUnits, []string{"0.0005"}, []uint64{3, even}, "0", nil},
+		{"invalid", tcDecimalAdd, []string{"1e3", "1"}, []uint64{0, even}, "", ErrDecimalInvalid},
+		{"invalid point", tcDecimalAdd, []string{"1.", "1"}, []uint64{0, even}, "", ErrDecimalInvalid},
+		{"invalid scale", tcDecimalAdd, []string{"1", "1"}, []uint64{MaxDecimalScale + 1, even}, "", ErrDecimalScale},
+		{"invalid rounding", tcDecimalAdd, []string{"1", "1"}, []uint64{0, 3}, "", ErrDecimalRounding},
+		{"too long", tcDecimalAdd, []string{strings.Repeat("9", MaxDecimalDigits+3), "1"}, []uint64{0, even}, "", ErrDecimalInvalid},
+		{"overflow", tcDecimalMul, []string{strings.Repeat("9", 200), strings.Repeat("9", 200)}, []uint64{0, even}, "", ErrDecimalOverflow},
+	}
+	for _, tt := range tests {
+		var args []uint64
+		for _, s := range tt.args {
+			args = append(args, cstr(s))
+		}
+		args = append(args, tt.ints...)
+		p, err := tt.fn(eng, -1, args)
+		if err != tt.err {
+			t.Fatalf("%s: err %v, want %v", tt.name, err, tt.err)
+		}
+		if err != nil {
+			continue
+		}
+		if s, _ := vmem.GetString(p); string(s) != tt.want {
+			t.Fatalf("%s: %s, want %s", tt.name, s, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{{"1.50", "1.5", 0}, {"-0.1", "0", -1}, {"2", "1.999", 1}} {
+		v, err := tcDecimalCmp(eng, -1, []uint64{cstr(tt.a), cstr(tt.b)})
+		if err != nil || int(int64(v)) != tt.want {
+			t.Fatalf("cmp %s %s: %d, %v", tt.a, tt.b, int64(v), err)
+		}
+	}
+
+	short, _ := gasDecimalMul(eng, -1, []uint64{cstr("1.5"), cstr("2"), 2, even})
+	long, _ := gasDecimalMul(eng, -1, []uint64{cstr(strings.Repeat("9", 200)), cstr(strings.Repeat("9", 200)), 2, even})
+	if long <= short {
+		t.Fatalf("gas %d, %d", short, long)
+	}
+	huge := cstr(strings.Repeat("9", MaxDecimalDigits+3))
+	if _, err := gasDecimalMul(eng, -1, []uint64{huge, huge, 2, even}); err != ErrDecimalInvalid {
+		t.Fatalf("gas of %d digits: %v", MaxDecimalDigits+3, err)
+	}
+}
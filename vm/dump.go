@@ -0,0 +1,103 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+)
+
+// Dump is a post-mortem snapshot of the innermost contract frame of a failed
+// Run: its linear memory, wasm globals and stack, and its JSON objects and
+// arrays. Frame is nil if the app ran natively; the globals of native code are
+// kept outside the interpreter and are not included then.
+type Dump struct {
+	App     string                       `json:"app"`
+	Err     string                       `json:"err"`
+	Where   string                       `json:"where"`
+	Source  string                       `json:"source,omitempty"`
+	Frame   *Frame                       `json:"frame,omitempty"`
+	Globals []uint64                     `json:"globals,omitempty"`
+	Memory  []byte                       `json:"memory"`
+	JSON    []map[string]json.RawMessage `json:"json,omitempty"`
+	Arrays  [][]json.RawMessage          `json:"arrays,omitempty"`
+}
+
+// SetDumpOnFailure makes eng snapshot the failing frame of every failed Run,
+// see Engine.Dump.
+func (eng *Engine) SetDumpOnFailure(on bool) {
+	eng.dumpOnFailure = on
+}
+
+// Dump returns the snapshot of the last failed Run, or nil.
+func (eng *Engine) Dump() *Dump {
+	return eng.dump
+}
+
+func (eng *Engine) takeDump(app *APP, trap *Trap) {
+	d := &Dump{
+		App:    trap.App,
+		Err:    trap.Err.Error(),
+		Where:  trap.Where,
+		Frame:  app.CurrentFrame(),
+		Memory: append([]byte(nil), app.VM.Memory()...),
+	}
+	d.JSON, d.Arrays = app.jsonValues()
+	if trap.Source != nil {
+		d.Source = trap.Source.String()
+	}
+	if d.Frame != nil {
+		d.Globals = app.Globals()
+	}
+	eng.dump = d
+}
+
+// WriteFile writes the dump as JSON.
+func (d *Dump) WriteFile(path string) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// ReadDump reads a dump written by Dump.WriteFile.
+func ReadDump(path string) (*Dump, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d := new(Dump)
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DumpString is a printable string found in the memory of a Dump.
+type DumpString struct {
+	Addr uint64
+	Text string
+}
+
+// Strings returns the runs of at least min printable ASCII characters in the
+// dumped memory that contain substr.
+func (d *Dump) Strings(min int, substr string) []DumpString {
+	var list []DumpString
+	start := -1
+	for i := 0; i <= len(d.Memory); i++ {
+		if i < len(d.Memory) && d.Memory[i] >= 0x20 && d.Memory[i] < 0x7f {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= min {
+			s := d.Memory[start:i]
+			if bytes.Contains(s, []byte(substr)) {
+				list = append(list, DumpString{Addr: uint64(start), Text: string(s)})
+			}
+		}
+		start = -1
+	}
+	return list
+}
@@ -1,10 +1,10 @@
 package vm
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"runtime/debug"
+	"strings"
 	"sync"
 
 	"github.com/xunleichain/tc-wasm/mock/log"
@@ -46,6 +46,7 @@ type Engine struct {
 	isZeroAddr   bool
 	State        StateDB
 	AppCache     *sync.Map
+	aots         *AotService
 	Env          *EnvTable
 	AppFrames    []*APP
 	FrameIndex   int
@@ -56,7 +57,18 @@ type Engine struct {
 	Ctx          interface{}
 	fee          uint64
 
-	jsonCache []map[string]json.RawMessage
+	jsonMemory int // bytes held by the JSON handles of the transaction, see json.go
+
+	trap     *Trap
+	profile  *GasProfile
+	debugger *Debugger
+	coverage *Coverage
+	recorder *Recorder
+	replayer *Replayer
+
+	dumpOnFailure bool
+	dump          *Dump
+	verifying     bool
 }
 
 func NewEngine(c *Contract, gas uint64, db StateDB, logger log.Logger) *Engine {
@@ -64,12 +76,12 @@ func NewEngine(c *Contract, gas uint64, db StateDB, logger log.Logger) *Engine {
 		logger:     logger,
 		State:      db,
 		AppCache:   AppCache,
+		aots:       DefaultAotService(),
 		Env:        NewEnvTable(),
 		AppFrames:  make([]*APP, maxFrames),
 		FrameIndex: -1,
 		gas:        gas,
 		Contract:   c,
-		jsonCache:  make([]map[string]json.RawMessage, 0, 64),
 	}
 
 	return eng
@@ -84,7 +96,13 @@ func (eng *Engine) Logger() log.Logger {
 }
 
 func (eng *Engine) RemoveCache(name string) {
-	RemoveCache(name)
+	_app, ok := eng.AppCache.Load(name)
+	if !ok {
+		return
+	}
+
+	eng.aots.deleteNative(_app.(*APP))
+	eng.AppCache.Delete(name)
 }
 
 func (eng *Engine) AppByName(name string) *APP {
@@ -102,6 +120,9 @@ func (eng *Engine) UseGas(cost uint64) bool {
 	}
 	eng.gas -= cost
 	eng.gasUsed += cost
+	if eng.profile != nil {
+		eng.profile.add(eng.runningFrame, cost)
+	}
 	return true
 }
 
@@ -125,16 +146,38 @@ func (eng *Engine) SetTrace(isTrace bool) {
 
 // IsTracing implement Backend
 func (eng *Engine) IsTracing() bool {
-	return eng.isTrace
+	return eng.isTrace || eng.debugger != nil || eng.coverage != nil
 }
 
 // Trace implement Backend
 func (eng *Engine) Trace(msg string, v ...interface{}) {
+	if eng.coverage != nil && strings.HasPrefix(msg, "pc:") {
+		eng.coverage.step(eng.runningFrame)
+	}
+	if eng.debugger != nil {
+		eng.debugger.trace(eng, msg, v)
+	}
 	if eng.isTrace {
+		// per-instruction traces start with the interpreter pc
+		if app := eng.runningFrame; app != nil && strings.HasPrefix(msg, "pc:") {
+			if fnIndex, pc, ok := app.interpFrame(); ok {
+				where, line := app.locate(fnIndex, pc)
+				v = append(v, "at", where)
+				if line != nil {
+					v = append(v, "src", line.String())
+				}
+			}
+		}
 		eng.logger.Info(msg, v...)
 	}
 }
 
+// interpreterOnly reports whether native code must be bypassed because a
+// debug facility needs to observe every instruction.
+func (eng *Engine) interpreterOnly() bool {
+	return eng.debugger != nil || eng.coverage != nil || eng.verifying
+}
+
 func (eng *Engine) NewApp(name string, code []byte, debug bool) (*APP, error) {
 	if app := eng.AppByName(name); app != nil {
 		return app.Clone(eng), nil
@@ -195,10 +238,15 @@ func (eng *Engine) Run(app *APP, input []byte) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if eng.shouldVerify(app) {
+		return eng.runVerified(app, action, args)
+	}
 	return eng.run(app, action, args)
 }
 
 func (eng *Engine) run(app *APP, action, args string) (ret uint64, err error) {
+	top := eng.runningFrame == nil
+	gasUsed := eng.gasUsed
 	defer func() {
 		app.Close()
 		if r := recover(); r != nil {
@@ -210,8 +258,23 @@ func (eng *Engine) run(app *APP, action, args string) (ret uint64, err error) {
 				err = fmt.Errorf("exec: %v", e)
 			}
 		}
+		if top {
+			err = eng.recordReturn(app, ret, eng.gasUsed-gasUsed, err)
+		}
+		if err != nil {
+			eng.recordTrap(app, err)
+		}
+		eng.releaseJSON(app)
 	}()
 
+	if top {
+		eng.trap = nil
+		eng.dump = nil
+		if err := eng.recordRun(app, action, args); err != nil {
+			return 0, err
+		}
+	}
+
 	if string(action) == "Init" || string(action) == "init" {
 		if !eng.Contract.CreateCall {
 			return 0, ErrInitEngine
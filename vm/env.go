@@ -38,78 +38,142 @@ func init() {
 
 	gEnvTable = &env
 
-	gEnvTable.RegisterFunc("TC_CallContract", new(TCCallContract))
-	gEnvTable.RegisterFunc("TC_DelegateCallContract", new(TCDelegateCallContract))
-
-	gEnvTable.RegisterFunc("TC_BigIntAdd", new(TCBigIntAdd))
-	gEnvTable.RegisterFunc("TC_BigIntSub", new(TCBigIntSub))
-	gEnvTable.RegisterFunc("TC_BigIntMul", new(TCBigIntMul))
-	gEnvTable.RegisterFunc("TC_BigIntDiv", new(TCBigIntDiv))
-	gEnvTable.RegisterFunc("TC_BigIntMod", new(TCBigIntMod))
-	gEnvTable.RegisterFunc("TC_BigIntCmp", new(TCBigIntCmp))
-	gEnvTable.RegisterFunc("TC_BigIntToInt64", new(TCBigIntToInt64))
-
-	gEnvTable.RegisterFunc("exit", new(TCExit))
-	gEnvTable.RegisterFunc("abort", new(TCAbort))
-	gEnvTable.RegisterFunc("malloc", new(TCMalloc))
-	gEnvTable.RegisterFunc("calloc", new(TCCalloc))
-	gEnvTable.RegisterFunc("realloc", new(TCRealloc))
-	gEnvTable.RegisterFunc("prints_l", new(TCPrintsl))
-	gEnvTable.RegisterFunc("free", new(TCFree))
-	gEnvTable.RegisterFunc("memcpy", new(TCMemcpy))
-	gEnvTable.RegisterFunc("memset", new(TCMemset))
-	gEnvTable.RegisterFunc("memmove", new(TCMemmove))
-	gEnvTable.RegisterFunc("memcmp", new(TCMemcmp))
-	gEnvTable.RegisterFunc("strcmp", new(TCStrcmp))
-	gEnvTable.RegisterFunc("strcpy", new(TCStrcpy))
-	gEnvTable.RegisterFunc("strlen", new(TCStrlen))
-	gEnvTable.RegisterFunc("strconcat", new(TCStrconcat))
-	gEnvTable.RegisterFunc("atoi", new(TCAtoi))
-	gEnvTable.RegisterFunc("atoi64", new(TCAtoi64))
-	//	gEnvTable.RegisterFunc("atof32", new(TCAtof32))
-	//	gEnvTable.RegisterFunc("atof64", new(TCAtof64))
-	gEnvTable.RegisterFunc("itoa", new(TCItoa))
-	gEnvTable.RegisterFunc("i64toa", new(TCI64toa))
-
-	gEnvTable.RegisterFunc("TC_GetMsgData", new(TCGetMsgData))
-	gEnvTable.RegisterFunc("TC_GetMsgGas", new(TCGetMsgGas))
-	gEnvTable.RegisterFunc("TC_GetMsgSender", new(TCGetMsgSender))
-	gEnvTable.RegisterFunc("TC_GetMsgSign", new(TCGetMsgSign))
-	gEnvTable.RegisterFunc("TC_Assert", new(TCAssert))
-	gEnvTable.RegisterFunc("TC_Require", new(TCRequire))
-	gEnvTable.RegisterFunc("TC_GasLeft", new(TCGasLeft))
-	gEnvTable.RegisterFunc("TC_RequireWithMsg", new(TCRequireWithMsg))
-	gEnvTable.RegisterFunc("TC_Revert", new(TCRevert))
-	gEnvTable.RegisterFunc("TC_RevertWithMsg", new(TCRevertWithMsg))
-	gEnvTable.RegisterFunc("TC_IsHexAddress", new(TCIsHexAddress))
-	gEnvTable.RegisterFunc("TC_Payable", new(TCPayable))
-
-	gEnvTable.RegisterFunc("TC_Prints", new(TCPrints))
-	gEnvTable.RegisterFunc("TC_GetSelfAddress", new(TCGetSelfAddress))
-	gEnvTable.RegisterFunc("TC_Ripemd160", new(TCRipemd160))
-	gEnvTable.RegisterFunc("TC_Sha256", new(TCSha256))
-	gEnvTable.RegisterFunc("TC_Keccak256", new(TCKeccak256))
+	gEnvTable.RegisterStateFunc("TC_CallContract", new(TCCallContract), "sss:s")
+	gEnvTable.RegisterStateFunc("TC_DelegateCallContract", new(TCDelegateCallContract), "sss:s")
+
+	gEnvTable.RegisterPureFunc("TC_BigIntAdd", new(TCBigIntAdd))
+	gEnvTable.RegisterPureFunc("TC_BigIntSub", new(TCBigIntSub))
+	gEnvTable.RegisterPureFunc("TC_BigIntMul", new(TCBigIntMul))
+	gEnvTable.RegisterPureFunc("TC_BigIntDiv", new(TCBigIntDiv))
+	gEnvTable.RegisterPureFunc("TC_BigIntMod", new(TCBigIntMod))
+	gEnvTable.RegisterPureFunc("TC_BigIntCmp", new(TCBigIntCmp))
+	gEnvTable.RegisterPureFunc("TC_BigIntToInt64", new(TCBigIntToInt64))
+	gEnvTable.RegisterPureFunc("TC_BigIntCheckedAdd", new(TCBigIntCheckedAdd))
+	gEnvTable.RegisterPureFunc("TC_BigIntCheckedSub", new(TCBigIntCheckedSub))
+	gEnvTable.RegisterPureFunc("TC_BigIntCheckedMul", new(TCBigIntCheckedMul))
+	gEnvTable.RegisterPureFunc("TC_BigIntCheckedDiv", new(TCBigIntCheckedDiv))
+	gEnvTable.RegisterPureFunc("TC_BigIntCheckedMod", new(TCBigIntCheckedMod))
+	gEnvTable.RegisterPureFunc("TC_BigIntPow", new(TCBigIntPow))
+	gEnvTable.RegisterPureFunc("TC_BigIntModExp", new(TCBigIntModExp))
+	gEnvTable.RegisterPureFunc("TC_BigIntSqrt", new(TCBigIntSqrt))
+	gEnvTable.RegisterPureFunc("TC_BigIntAbs", new(TCBigIntAbs))
+	gEnvTable.RegisterPureFunc("TC_BigIntAnd", new(TCBigIntAnd))
+	gEnvTable.RegisterPureFunc("TC_BigIntOr", new(TCBigIntOr))
+	gEnvTable.RegisterPureFunc("TC_BigIntXor", new(TCBigIntXor))
+	gEnvTable.RegisterPureFunc("TC_BigIntNot", new(TCBigIntNot))
+	gEnvTable.RegisterPureFunc("TC_BigIntShl", new(TCBigIntShl))
+	gEnvTable.RegisterPureFunc("TC_BigIntShr", new(TCBigIntShr))
+	gEnvTable.RegisterPureFunc("TC_BigIntMin", new(TCBigIntMin))
+	gEnvTable.RegisterPureFunc("TC_BigIntMax", new(TCBigIntMax))
+	gEnvTable.RegisterPureFunc("TC_BigIntToBytes", new(TCBigIntToBytes))
+	gEnvTable.RegisterPureFunc("TC_BigIntFromBytes", new(TCBigIntFromBytes))
+
+	gEnvTable.RegisterPureFunc("TC_DecimalAdd", new(TCDecimalAdd))
+	gEnvTable.RegisterPureFunc("TC_DecimalSub", new(TCDecimalSub))
+	gEnvTable.RegisterPureFunc("TC_DecimalMul", new(TCDecimalMul))
+	gEnvTable.RegisterPureFunc("TC_DecimalDiv", new(TCDecimalDiv))
+	gEnvTable.RegisterPureFunc("TC_DecimalCmp", new(TCDecimalCmp))
+	gEnvTable.RegisterPureFunc("TC_DecimalRescale", new(TCDecimalRescale))
+	gEnvTable.RegisterPureFunc("TC_DecimalFormat", new(TCDecimalFormat))
+	gEnvTable.RegisterPureFunc("TC_DecimalToUnits", new(TCDecimalToUnits))
+
+	gEnvTable.RegisterFrameFunc("exit", new(TCExit))
+	gEnvTable.RegisterFrameFunc("abort", new(TCAbort))
+	gEnvTable.RegisterFrameFunc("malloc", new(TCMalloc))
+	gEnvTable.RegisterFrameFunc("calloc", new(TCCalloc))
+	gEnvTable.RegisterFrameFunc("realloc", new(TCRealloc))
+	gEnvTable.RegisterFrameFunc("prints_l", new(TCPrintsl))
+	gEnvTable.RegisterFrameFunc("free", new(TCFree))
+	gEnvTable.RegisterPureFunc("memcpy", new(TCMemcpy))
+	gEnvTable.RegisterPureFunc("memset", new(TCMemset))
+	gEnvTable.RegisterPureFunc("memmove", new(TCMemmove))
+	gEnvTable.RegisterPureFunc("memcmp", new(TCMemcmp))
+	gEnvTable.RegisterPureFunc("strcmp", new(TCStrcmp))
+	gEnvTable.RegisterPureFunc("strcpy", new(TCStrcpy))
+	gEnvTable.RegisterPureFunc("strlen", new(TCStrlen))
+	gEnvTable.RegisterPureFunc("strconcat", new(TCStrconcat))
+	gEnvTable.RegisterPureFunc("atoi", new(TCAtoi))
+	gEnvTable.RegisterPureFunc("atoi64", new(TCAtoi64))
+	//	gEnvTable.RegisterPureFunc("atof32", new(TCAtof32))
+	//	gEnvTable.RegisterPureFunc("atof64", new(TCAtof64))
+	gEnvTable.RegisterPureFunc("itoa", new(TCItoa))
+	gEnvTable.RegisterPureFunc("i64toa", new(TCI64toa))
+
+	gEnvTable.RegisterFrameFunc("TC_GetMsgData", new(TCGetMsgData))
+	gEnvTable.RegisterFrameFunc("TC_GetMsgGas", new(TCGetMsgGas))
+	gEnvTable.RegisterFrameFunc("TC_GetMsgSender", new(TCGetMsgSender))
+	gEnvTable.RegisterFrameFunc("TC_GetMsgSign", new(TCGetMsgSign))
+	gEnvTable.RegisterPureFunc("TC_Assert", new(TCAssert))
+	gEnvTable.RegisterPureFunc("TC_Require", new(TCRequire))
+	gEnvTable.RegisterFrameFunc("TC_GasLeft", new(TCGasLeft))
+	gEnvTable.RegisterPureFunc("TC_RequireWithMsg", new(TCRequireWithMsg))
+	gEnvTable.RegisterPureFunc("TC_Revert", new(TCRevert))
+	gEnvTable.RegisterPureFunc("TC_RevertWithMsg", new(TCRevertWithMsg))
+	gEnvTable.RegisterPureFunc("TC_IsHexAddress", new(TCIsHexAddress))
+	gEnvTable.RegisterFrameFunc("TC_Payable", new(TCPayable))
+
+	gEnvTable.RegisterFrameFunc("TC_Prints", new(TCPrints))
+	gEnvTable.RegisterFrameFunc("TC_GetSelfAddress", new(TCGetSelfAddress))
+	gEnvTable.RegisterPureFunc("TC_Ripemd160", new(TCRipemd160))
+	gEnvTable.RegisterPureFunc("TC_Sha256", new(TCSha256))
+	gEnvTable.RegisterPureFunc("TC_Keccak256", new(TCKeccak256))
+	gEnvTable.RegisterPureFunc("TC_Keccak256Bytes", new(TCKeccak256Bytes))
+	gEnvTable.RegisterPureFunc("TC_Sha256Bytes", new(TCSha256Bytes))
+	gEnvTable.RegisterPureFunc("TC_Ripemd160Bytes", new(TCRipemd160Bytes))
+	gEnvTable.RegisterPureFunc("TC_Sha3_256", new(TCSha3_256))
+	gEnvTable.RegisterPureFunc("TC_Sha512", new(TCSha512))
+	gEnvTable.RegisterPureFunc("TC_Blake2b256", new(TCBlake2b256))
+	gEnvTable.RegisterPureFunc("TC_Blake2s256", new(TCBlake2s256))
+	gEnvTable.RegisterPureFunc("TC_HmacSha256", new(TCHmacSha256))
+	gEnvTable.RegisterPureFunc("TC_Bn256Add", new(TCBn256Add))
+	gEnvTable.RegisterPureFunc("TC_Bn256ScalarMul", new(TCBn256ScalarMul))
+	gEnvTable.RegisterPureFunc("TC_Bn256Pairing", new(TCBn256Pairing))
+	gEnvTable.RegisterPureFunc("TC_Ed25519Verify", new(TCEd25519Verify))
+	gEnvTable.RegisterPureFunc("TC_P256Verify", new(TCP256Verify))
+	gEnvTable.RegisterPureFunc("TC_VerifyMerkleProof", new(TCVerifyMerkleProof))
+	gEnvTable.RegisterPureFunc("TC_VerifyMerkleProofAt", new(TCVerifyMerkleProofAt))
 
 	// go json api (optional)
-	gEnvTable.RegisterFunc("TC_JsonParse", new(TCJSONParse))
-	gEnvTable.RegisterFunc("TC_JsonGetInt", new(TCJSONGetInt))
-	gEnvTable.RegisterFunc("TC_JsonGetInt64", new(TCJSONGetInt64))
-	gEnvTable.RegisterFunc("TC_JsonGetString", new(TCJSONGetString))
-	gEnvTable.RegisterFunc("TC_JsonGetAddress", new(TCJSONGetAddress))
-	gEnvTable.RegisterFunc("TC_JsonGetBigInt", new(TCJSONGetBigInt))
-	gEnvTable.RegisterFunc("TC_JsonGetFloat", new(TCJSONGetFloat))
-	gEnvTable.RegisterFunc("TC_JsonGetDouble", new(TCJSONGetDouble))
-	gEnvTable.RegisterFunc("TC_JsonGetObject", new(TCJSONGetObject))
-	gEnvTable.RegisterFunc("TC_JsonNewObject", new(TCJSONNewObject))
-	gEnvTable.RegisterFunc("TC_JsonPutInt", new(TCJSONPutInt))
-	gEnvTable.RegisterFunc("TC_JsonPutInt64", new(TCJSONPutInt64))
-	gEnvTable.RegisterFunc("TC_JsonPutString", new(TCJSONPutString))
-	gEnvTable.RegisterFunc("TC_JsonPutAddress", new(TCJSONPutAddress))
-	gEnvTable.RegisterFunc("TC_JsonPutBigInt", new(TCJSONPutBigInt))
-	gEnvTable.RegisterFunc("TC_JsonPutFloat", new(TCJSONPutFloat))
-	gEnvTable.RegisterFunc("TC_JsonPutDouble", new(TCJSONPutDouble))
-	gEnvTable.RegisterFunc("TC_JsonPutObject", new(TCJSONPutObject))
-	gEnvTable.RegisterFunc("TC_JsonToString", new(TCJSONToString))
+	gEnvTable.RegisterFrameFunc("TC_JsonParse", new(TCJSONParse))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetInt", new(TCJSONGetInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetInt64", new(TCJSONGetInt64))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetString", new(TCJSONGetString))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetAddress", new(TCJSONGetAddress))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetBigInt", new(TCJSONGetBigInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetFloat", new(TCJSONGetFloat))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetDouble", new(TCJSONGetDouble))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetObject", new(TCJSONGetObject))
+	gEnvTable.RegisterFrameFunc("TC_JsonNewObject", new(TCJSONNewObject))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutInt", new(TCJSONPutInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutInt64", new(TCJSONPutInt64))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutString", new(TCJSONPutString))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutAddress", new(TCJSONPutAddress))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutBigInt", new(TCJSONPutBigInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutFloat", new(TCJSONPutFloat))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutDouble", new(TCJSONPutDouble))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutObject", new(TCJSONPutObject))
+	gEnvTable.RegisterFrameFunc("TC_JsonToString", new(TCJSONToString))
+	gEnvTable.RegisterFrameFunc("TC_JsonParseArray", new(TCJSONParseArray))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayLen", new(TCJSONArrayLen))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayGetInt", new(TCJSONArrayGetInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayGetInt64", new(TCJSONArrayGetInt64))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayGetString", new(TCJSONArrayGetString))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayGetObject", new(TCJSONArrayGetObject))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetArray", new(TCJSONGetArray))
+	gEnvTable.RegisterFrameFunc("TC_JsonNewArray", new(TCJSONNewArray))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayPushInt", new(TCJSONArrayPushInt))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayPushInt64", new(TCJSONArrayPushInt64))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayPushString", new(TCJSONArrayPushString))
+	gEnvTable.RegisterFrameFunc("TC_JsonArrayPushObject", new(TCJSONArrayPushObject))
+	gEnvTable.RegisterFrameFunc("TC_JsonPutArray", new(TCJSONPutArray))
+	gEnvTable.RegisterFrameFunc("TC_JsonFree", new(TCJSONFree))
+	gEnvTable.RegisterFrameFunc("TC_JsonHas", new(TCJSONHas))
+	gEnvTable.RegisterFrameFunc("TC_JsonIsNull", new(TCJSONIsNull))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetBool", new(TCJSONGetBool))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetIntOr", new(TCJSONGetIntOr))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetInt64Or", new(TCJSONGetInt64Or))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetBoolOr", new(TCJSONGetBoolOr))
+	gEnvTable.RegisterFrameFunc("TC_JsonGetStringOr", new(TCJSONGetStringOr))
 }
 
 // NewEnvTable new EnvTable
@@ -124,7 +188,9 @@ func (env *EnvTable) resolveImport(name string) (*wasm.Module, error) {
 	return &env.Module, nil
 }
 
-// RegisterFunc Register env function for wasm module
+// RegisterFunc Register env function for wasm module. Verified runs skip the
+// contracts importing it, see RegisterPureFunc, RegisterFrameFunc and
+// RegisterStateFunc.
 func (env *EnvTable) RegisterFunc(name string, fn EnvFunc) {
 	if entry, exist := env.Exports.Entries[name]; exist {
 		env.Module.FunctionIndexSpace[entry.Index].Host = fn
@@ -163,6 +229,21 @@ func (env *EnvTable) RegisterGlobal(name string, v interface{}) {
 	env.importGlobalCnt++
 }
 
+// funcIndex returns the index of the function name in env.
+func (env *EnvTable) funcIndex(name string) (int, bool) {
+	entry, exist := env.Exports.Entries[name]
+	if !exist || entry.Kind != wasm.ExternalFunction {
+		return 0, false
+	}
+	return int(entry.Index), true
+}
+
+// funcByIndex returns the function at index, as returned by funcIndex.
+func (env *EnvTable) funcByIndex(index int) (string, EnvFunc) {
+	fn := &env.Module.FunctionIndexSpace[index]
+	return fn.Name, fn.Host.(EnvFunc)
+}
+
 // GetFuncByName Get env function by name
 func (env *EnvTable) GetFuncByName(name string) EnvFunc {
 	if entry, exist := env.Exports.Entries[name]; exist {
@@ -31,6 +31,25 @@ var (
 	ErrContractAssert           = errors.New("vm: contract assert fail")
 	ErrOutOfGas                 = errors.New("vm: out of gas")
 	ErrExecutionExit            = errors.New("vm: execution exit")
+	ErrReplayDiverged           = errors.New("vm: replay diverged from recording")
+	ErrNativeCrashed            = errors.New("vm: native code crashed")
+	ErrNoNative                 = errors.New("vm: no native code")
+	ErrAotNotRunning            = errors.New("vm: aot service not running")
+	ErrJSONHandle               = errors.New("vm: invalid json handle")
+	ErrJSONPath                 = errors.New("vm: invalid json path")
+	ErrJSONMemory               = errors.New("vm: json handle memory limit exceeded")
+	ErrBigIntMode               = errors.New("vm: invalid big int mode")
+	ErrBigIntInvalid            = errors.New("vm: invalid big int")
+	ErrBigIntOverflow           = errors.New("vm: big int overflow")
+	ErrBigIntDivZero            = errors.New("vm: big int division by zero")
+	ErrDecimalInvalid           = errors.New("vm: invalid decimal")
+	ErrDecimalScale             = errors.New("vm: invalid decimal scale")
+	ErrDecimalRounding          = errors.New("vm: invalid decimal rounding")
+	ErrDecimalOverflow          = errors.New("vm: decimal overflow")
+	ErrDecimalDivZero           = errors.New("vm: decimal division by zero")
+	ErrBn256Point               = errors.New("vm: invalid bn256 point")
+	ErrMerkleHashAlg            = errors.New("vm: unknown merkle proof hash algorithm")
+	ErrMerkleProof              = errors.New("vm: invalid merkle proof")
 )
 
 type Error struct {
@@ -85,10 +85,130 @@ func gasRipemd160(eng *Engine, index int64, args []uint64) (uint64, error) {
 	return gas, nil
 }
 
+// gasHashBytes returns the gas of a hash of n bytes.
+func gasHashBytes(n uint64, setGas, perWord uint64) (uint64, error) {
+	gas := GasExtStep + setGas
+	wordGas, overflow := SafeMul(ToWordSize(n), perWord)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(gas, wordGas); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+func gasKeccak256Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), HashSetGas, Sha3WordGas)
+}
+
+func gasSha256Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), HashSetGas, Sha256PerWordGas)
+}
+
+func gasRipemd160Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), AddrSetGas, Ripemd160PerWordGas)
+}
+
+func gasSha3_256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), HashSetGas, Sha3WordGas)
+}
+
+// gasSha512 charges two set words for the 64 bytes digest.
+func gasSha512(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), 2*HashSetGas, Sha512PerWordGas)
+}
+
+func gasBlake2b256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), HashSetGas, Blake2PerWordGas)
+}
+
+func gasBlake2s256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1])), HashSetGas, Blake2PerWordGas)
+}
+
+// gasHmacSha256 charges the key and the data, plus the two blocks of the
+// inner and outer pads of 64 bytes.
+func gasHmacSha256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasHashBytes(uint64(uint32(args[1]))+uint64(uint32(args[3]))+2*64, HashSetGas, Sha256PerWordGas)
+}
+
+// gasVerifyMerkleProof charges a hash of two words for each sibling of the
+// proof.
+func gasVerifyMerkleProof(eng *Engine, index int64, args []uint64) (uint64, error) {
+	runningFrame, _ := eng.RunningAppFrame()
+	vmem := runningFrame.VM.VMemory()
+	proofLen, err := vmem.Strlen(args[2])
+	if err != nil {
+		return 0, err
+	}
+	var levelGas uint64
+	switch args[3] {
+	case merkleKeccak256:
+		levelGas = Sha3Gas + 2*Sha3WordGas
+	case merkleSha256:
+		levelGas = Sha256BaseGas + 2*Sha256PerWordGas
+	default:
+		return 0, ErrMerkleHashAlg
+	}
+	// 64 hex digits per sibling, after an optional 0x
+	depth := uint64(proofLen) / 64
+	gas, overflow := SafeMul(depth, levelGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(gas, GasExtStep); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
 func GasEcrecover(eng *Engine, index int64, args []uint64) (uint64, error) {
 	return EcrecoverGas, nil
 }
 
+func gasBn256Add(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return Bn256AddGas, nil
+}
+
+func gasBn256ScalarMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return Bn256ScalarMulGas, nil
+}
+
+func gasBn256Pairing(eng *Engine, index int64, args []uint64) (uint64, error) {
+	pairs := uint64(uint32(args[1])) / 192
+	gas, overflow := SafeMul(pairs, Bn256PairingPerPointGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(gas, Bn256PairingBaseGas); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+// gasSigVerify returns the gas of a signature verification of a message of
+// args[1] bytes.
+func gasSigVerify(args []uint64, base, perWord uint64) (uint64, error) {
+	wordGas, overflow := SafeMul(ToWordSize(uint64(uint32(args[1]))), perWord)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	gas, overflow := SafeAdd(base, wordGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+func gasEd25519Verify(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasSigVerify(args, Ed25519VerifyGas, Ed25519PerWordGas)
+}
+
+func gasP256Verify(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasSigVerify(args, P256VerifyGas, P256PerWordGas)
+}
+
 func GasGetBalance(eng *Engine, index int64, args []uint64) (uint64, error) {
 	return GasTableEIP158.Balance, nil
 }
@@ -623,6 +743,284 @@ func gasBigIntToInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
 	return GasExtStep * 3, nil
 }
 
+// gasBigIntExt checks the numbers at ptrs and charges gas, BigIntWordGas per
+// word of the widest of them, or per word squared if quad, and the copy of the
+// result. The result is bounded from the operands, as a sum or as a product,
+// and by the values of the mode.
+func gasBigIntExt(eng *Engine, ptrs []uint64, modeArg uint64, gas uint64, quad bool) (uint64, error) {
+	xs, mode, err := bigIntArgs(eng, ptrs, modeArg)
+	if err != nil {
+		return 0, err
+	}
+	bits := bigIntMaxBits(xs)
+	resultBits := bits + 1
+	if quad {
+		resultBits = 2 * bits
+	}
+	return gasBigIntOp(bits, quad, resultBits, mode, gas)
+}
+
+// gasBigIntOp adds to gas the words of operands of bits and the copy of a
+// result of resultBits, at most as wide as the values of the mode. The
+// arguments fit in their mode, so the words are at most MaxBigIntBits/256.
+func gasBigIntOp(bits int, quad bool, resultBits int, mode int, gas uint64) (uint64, error) {
+	words := uint64(bits+255) / 256
+	if quad {
+		words *= words
+	}
+	gas, overflow := SafeAdd(gas, words*BigIntWordGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	// the complement of an unbounded number has one more bit
+	if max := bigIntBits(mode) + 1; resultBits > max {
+		resultBits = max
+	}
+	return gasBigIntCopy(resultBits, gas)
+}
+
+func bigIntMaxBits(xs []*big.Int) int {
+	bits := 0
+	for _, x := range xs {
+		if n := x.BitLen(); n > bits {
+			bits = n
+		}
+	}
+	return bits
+}
+
+// gasBigIntCopy adds to gas the copy of the decimal string of a number of
+// bits.
+func gasBigIntCopy(bits int, gas uint64) (uint64, error) {
+	wordGas, overflow := SafeMul(ToWordSize(bigIntStringLen(bits)), CopyGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(gas, wordGas); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+func gasBigIntCheckedAdd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntCheckedSub(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntCheckedMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, true)
+}
+
+func gasBigIntCheckedDiv(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, true)
+}
+
+func gasBigIntCheckedMod(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, true)
+}
+
+// gasBigIntPow charges as the EXP instruction, per byte of the exponent, and
+// BigIntWordGas per byte of the exponent and word squared of the result.
+func gasBigIntPow(eng *Engine, index int64, args []uint64) (uint64, error) {
+	xs, mode, err := bigIntArgs(eng, args[:2], args[2])
+	if err != nil {
+		return 0, err
+	}
+	// the result has at most bits(a)*b bits, bigIntPow fails above the mode
+	resultBits := bigIntBits(mode)
+	if n := xs[0].BitLen(); n <= 1 {
+		resultBits = n
+	} else if xs[1].IsInt64() && xs[1].Sign() >= 0 && xs[1].Int64() < int64(resultBits/n) {
+		resultBits = n * int(xs[1].Int64())
+	}
+	words := uint64(resultBits+255) / 256
+	expGas, overflow := SafeMul(uint64((xs[1].BitLen()+7)/8), ExpByteGas+words*words*BigIntWordGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	gas, overflow := SafeAdd(GasExtStep*3+ExpGas, expGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	return gasBigIntCopy(resultBits, gas)
+}
+
+// gasBigIntModExp charges as the modexp precompiled contract (EIP-198). The
+// result is less than the modulus.
+func gasBigIntModExp(eng *Engine, index int64, args []uint64) (uint64, error) {
+	xs, _, err := bigIntArgs(eng, args[:3], args[3])
+	if err != nil {
+		return 0, err
+	}
+	size := uint64((xs[0].BitLen() + 7) / 8)
+	if n := uint64((xs[2].BitLen() + 7) / 8); n > size {
+		size = n
+	}
+	var mult uint64
+	switch {
+	case size <= 64:
+		mult = size * size
+	case size <= 1024:
+		mult = size*size/4 + 96*size - 3072
+	default:
+		mult = size*size/16 + 480*size - 199680
+	}
+	adjExpLen := uint64(1)
+	if n := xs[1].BitLen(); n > 1 {
+		adjExpLen = uint64(n - 1)
+	}
+	gas, overflow := SafeMul(mult, adjExpLen)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(GasExtStep*3, gas/ModExpQuadCoeffDiv); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gasBigIntCopy(xs[2].BitLen(), gas)
+}
+
+func gasBigIntSqrt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:1], args[1], GasExtStep*3, true)
+}
+
+func gasBigIntAbs(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:1], args[1], GasExtStep*3, false)
+}
+
+func gasBigIntAnd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntXor(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntNot(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:1], args[1], GasExtStep*3, false)
+}
+
+// gasBigIntShl charges as gasBigIntExt, for a result as wide as the operand
+// and the shift.
+func gasBigIntShl(eng *Engine, index int64, args []uint64) (uint64, error) {
+	xs, mode, err := bigIntArgs(eng, args[:1], args[2])
+	if err != nil {
+		return 0, err
+	}
+	bits := xs[0].BitLen()
+	resultBits := bigIntBits(mode) + 1
+	if n := int32(args[1]); n >= 0 && int(n) < resultBits-bits {
+		resultBits = bits + int(n)
+	}
+	return gasBigIntOp(bits, false, resultBits, mode, GasExtStep*3)
+}
+
+func gasBigIntShr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:1], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntMin(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntMax(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasBigIntExt(eng, args[:2], args[2], GasExtStep*3, false)
+}
+
+func gasBigIntToBytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	size, err := bigIntByteSize(args[1])
+	if err != nil {
+		return 0, err
+	}
+	return GasExtStep*3 + ToWordSize(uint64(size))*CopyGas, nil
+}
+
+func gasBigIntFromBytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	size, err := bigIntByteSize(args[1])
+	if err != nil {
+		return 0, err
+	}
+	// 32 bytes are at most 78 digits
+	return GasExtStep*3 + ToWordSize(uint64(size)*5/2+1)*CopyGas, nil
+}
+
+// gasDecimal charges for the lengths of the decimal strings at ptrs and of
+// the extra digits of the result, their product if mul. Strings longer than
+// the operands of parseDecimal fail as in the call.
+func gasDecimal(eng *Engine, ptrs []uint64, extra uint64, mul bool) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	words := make([]uint64, len(ptrs))
+	sum := extra
+	for i, ptr := range ptrs {
+		n, err := vmem.Strlen(ptr)
+		if err != nil {
+			return 0, err
+		}
+		if n > MaxDecimalDigits+2 {
+			return 0, ErrDecimalInvalid
+		}
+		words[i] = ToWordSize(uint64(n))
+		sum += uint64(n)
+	}
+	wordGas, overflow := SafeMul(ToWordSize(sum), CopyGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if mul {
+		mulGas, overflow := SafeMul(words[0]*words[1], CopyGas)
+		if overflow {
+			return 0, ErrGasOverflow
+		}
+		if wordGas, overflow = SafeAdd(wordGas, mulGas); overflow {
+			return 0, ErrGasOverflow
+		}
+	}
+	gas, overflow := SafeAdd(GasExtStep*3, wordGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+func gasDecimalAdd(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:2], uint64(uint32(args[2])), false)
+}
+
+func gasDecimalSub(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:2], uint64(uint32(args[2])), false)
+}
+
+func gasDecimalMul(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:2], uint64(uint32(args[2])), true)
+}
+
+func gasDecimalDiv(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:2], uint64(uint32(args[2])), true)
+}
+
+func gasDecimalCmp(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:2], 0, false)
+}
+
+func gasDecimalRescale(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:1], uint64(uint32(args[1])), false)
+}
+
+func gasDecimalFormat(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:1], uint64(uint32(args[1])), false)
+}
+
+func gasDecimalToUnits(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasDecimal(eng, args[:1], uint64(uint32(args[1])), false)
+}
+
 func GasBlockHash(eng *Engine, index int64, args []uint64) (uint64, error) {
 	return GasExtStep + HashSetGas, nil
 }
@@ -774,89 +1172,35 @@ func gasJSONParse(eng *Engine, index int64, args []uint64) (uint64, error) {
 }
 
 func gasJSONGetInt(eng *Engine, index int64, args []uint64) (uint64, error) {
-	return GasExtStep, nil
+	return gasJSONGet(eng, args, GasExtStep, false)
 }
 
 func gasJSONGetInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
-	return GasExtStep, nil
+	return gasJSONGet(eng, args, GasExtStep, false)
 }
 
 func gasJSONGetString(eng *Engine, index int64, args []uint64) (uint64, error) {
-	app, _ := eng.RunningAppFrame()
-	vmem := app.VM.VMemory()
-	root := int(args[0])
-	key, err := vmem.GetString(args[1])
-	if err != nil {
-		return 0, err
-	}
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	dataLen := len(v)
-	gas := GasExtStep
-	wordGas, overflow := SafeMul(ToWordSize(uint64(dataLen)), MemoryGas)
-	if overflow {
-		return 0, ErrGasOverflow
-	}
-	if gas, overflow = SafeAdd(gas, wordGas); overflow {
-		return 0, ErrGasOverflow
-	}
-	return gas, nil
+	return gasJSONGet(eng, args, GasExtStep, true)
 }
 
 func gasJSONGetAddress(eng *Engine, index int64, args []uint64) (uint64, error) {
-	return GasExtStep + AddrSetGas, nil
+	return gasJSONGet(eng, args, GasExtStep+AddrSetGas, false)
 }
 
 func gasJSONGetBigInt(eng *Engine, index int64, args []uint64) (uint64, error) {
-	app, _ := eng.RunningAppFrame()
-	vmem := app.VM.VMemory()
-	root := int(args[0])
-	key, err := vmem.GetString(args[1])
-	if err != nil {
-		return 0, err
-	}
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	dataLen := len(v)
-	gas := GasExtStep
-	wordGas, overflow := SafeMul(ToWordSize(uint64(dataLen)), MemoryGas)
-	if overflow {
-		return 0, ErrGasOverflow
-	}
-	if gas, overflow = SafeAdd(gas, wordGas); overflow {
-		return 0, ErrGasOverflow
-	}
-	return gas, nil
+	return gasJSONGet(eng, args, GasExtStep, true)
 }
 
 func gasJSONGetFloat(eng *Engine, index int64, args []uint64) (uint64, error) {
-	return GasExtStep, nil
+	return gasJSONGet(eng, args, GasExtStep, false)
 }
 
 func gasJSONGetDouble(eng *Engine, index int64, args []uint64) (uint64, error) {
-	return GasExtStep, nil
+	return gasJSONGet(eng, args, GasExtStep, false)
 }
 
 func gasJSONGetObject(eng *Engine, index int64, args []uint64) (uint64, error) {
-	app, _ := eng.RunningAppFrame()
-	vmem := app.VM.VMemory()
-	root := int(args[0])
-	key, err := vmem.GetString(args[1])
-	if err != nil {
-		return 0, err
-	}
-	obj := eng.jsonCache[root]
-	v := obj[string(key)]
-	dataLen := len(v)
-	gas := JsonGas
-	wordGas, overflow := SafeMul(ToWordSize(uint64(dataLen)), MemoryGas)
-	if overflow {
-		return 0, ErrGasOverflow
-	}
-	if gas, overflow = SafeAdd(gas, wordGas); overflow {
-		return 0, ErrGasOverflow
-	}
-	return gas, nil
+	return gasJSONGet(eng, args, JsonGas, true)
 }
 
 func gasJSONNewObject(eng *Engine, index int64, args []uint64) (uint64, error) {
@@ -1015,8 +1359,10 @@ func gasJSONPutObject(eng *Engine, index int64, args []uint64) (uint64, []byte,
 	if err != nil {
 		return 0, nil, err
 	}
-	child := int(args[2])
-	childObj := eng.jsonCache[child]
+	childObj, err := eng.jsonObject(args[2])
+	if err != nil {
+		return 0, nil, err
+	}
 	childJSON, err := json.Marshal(childObj)
 	if err != nil {
 		return 0, nil, err
@@ -1036,8 +1382,11 @@ func gasJSONPutObject(eng *Engine, index int64, args []uint64) (uint64, []byte,
 }
 
 func gasJSONToString(eng *Engine, index int64, args []uint64) (uint64, []byte, error) {
-	root := int(args[0])
-	obj := eng.jsonCache[root]
+	root := args[0]
+	obj, err := eng.jsonObject(root)
+	if err != nil {
+		return 0, nil, err
+	}
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return 0, nil, err
@@ -1105,3 +1454,162 @@ func gasDelegateCallContract(eng *Engine, index int64, args []uint64) (uint64, e
 	}
 	return gas, nil
 }
+
+// gasJSONData charges MemoryGas per word of dataLen on top of gas.
+func gasJSONData(gas uint64, dataLen int) (uint64, error) {
+	wordGas, overflow := SafeMul(ToWordSize(uint64(dataLen)), MemoryGas)
+	if overflow {
+		return 0, ErrGasOverflow
+	}
+	if gas, overflow = SafeAdd(gas, wordGas); overflow {
+		return 0, ErrGasOverflow
+	}
+	return gas, nil
+}
+
+// gasJSONGet charges for the lookup of the key args[1] in the object args[0]:
+// the nested values decoded along its path, and the value itself if withValue.
+// The path is walked once, the Call takes the value from app.result.
+func gasJSONGet(eng *Engine, args []uint64, gas uint64, withValue bool) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	key, err := vmem.GetString(args[1])
+	if err != nil {
+		return 0, err
+	}
+	v, decoded, err := eng.jsonWalk(args[0], string(key))
+	if err != nil {
+		return 0, err
+	}
+	app.result = &jsonLookupResult{h: args[0], path: string(key), v: v, decoded: decoded}
+	if withValue {
+		decoded += len(v)
+	}
+	return gasJSONData(gas, decoded)
+}
+
+func gasJSONParseArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	dataLen, err := vmem.Strlen(args[0])
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(JsonGas, dataLen)
+}
+
+func gasJSONArrayLen(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasQuickStep, nil
+}
+
+func gasJSONArrayGetInt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasExtStep, nil
+}
+
+func gasJSONArrayGetInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasExtStep, nil
+}
+
+func gasJSONArrayGetString(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(GasExtStep, len(v))
+}
+
+func gasJSONArrayGetObject(eng *Engine, index int64, args []uint64) (uint64, error) {
+	v, err := jsonArrayElem(eng, args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(JsonGas, len(v))
+}
+
+func gasJSONGetArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, JsonGas, true)
+}
+
+func gasJSONNewArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasExtStep, nil
+}
+
+func gasJSONArrayPushInt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasExtStep, nil
+}
+
+func gasJSONArrayPushInt64(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasExtStep, nil
+}
+
+func gasJSONArrayPushString(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	valLen, err := vmem.Strlen(args[1])
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(GasExtStep, valLen)
+}
+
+func gasJSONArrayPushObject(eng *Engine, index int64, args []uint64) (uint64, error) {
+	childObj, err := eng.jsonObject(args[1])
+	if err != nil {
+		return 0, err
+	}
+	childJSON, err := json.Marshal(childObj)
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(GasExtStep, len(childJSON))
+}
+
+func gasJSONPutArray(eng *Engine, index int64, args []uint64) (uint64, error) {
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	keyLen, err := vmem.Strlen(args[1])
+	if err != nil {
+		return 0, err
+	}
+	arr, err := eng.jsonArray(args[2])
+	if err != nil {
+		return 0, err
+	}
+	arrJSON, err := json.Marshal(arr)
+	if err != nil {
+		return 0, err
+	}
+	return gasJSONData(GasExtStep, keyLen+len(arrJSON))
+}
+
+func gasJSONFree(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return GasQuickStep, nil
+}
+
+func gasJSONHas(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONIsNull(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONGetBool(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONGetIntOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONGetInt64Or(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONGetBoolOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, false)
+}
+
+func gasJSONGetStringOr(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return gasJSONGet(eng, args, GasExtStep, true)
+}
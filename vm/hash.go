@@ -0,0 +1,184 @@
+package vm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+// go byte hash api
+//
+// The hashes of TC_Keccak256, TC_Sha256 and TC_Ripemd160 stop at the first
+// zero byte of their string. The functions below hash the len bytes at data
+// instead, and return the hash as a 0x prefixed hex string as well.
+
+// hashBytesArg returns the args[lenArg] bytes at args[lenArg-1].
+func hashBytesArg(eng *Engine, args []uint64, lenArg int) ([]byte, error) {
+	runningFrame, _ := eng.RunningAppFrame()
+	vmem := runningFrame.VM.VMemory()
+	n := int(int32(args[lenArg]))
+	if n < 0 {
+		return nil, ErrInvalidApiArgs
+	}
+	data, err := vmem.GetBytes(args[lenArg-1], n)
+	if err != nil {
+		return nil, ErrInvalidApiArgs
+	}
+	return data, nil
+}
+
+// hashBytes returns the hex string of the hash by h of data(args[0], args[1]).
+func hashBytes(eng *Engine, args []uint64, h hash.Hash) (uint64, error) {
+	data, err := hashBytesArg(eng, args, 1)
+	if err != nil {
+		return 0, err
+	}
+	h.Write(data)
+	runningFrame, _ := eng.RunningAppFrame()
+	return runningFrame.VM.VMemory().SetBytes([]byte(fmt.Sprintf("0x%x", h.Sum(nil))))
+}
+
+type TCKeccak256Bytes struct{}
+
+func (t *TCKeccak256Bytes) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcKeccak256Bytes(eng, index, args)
+}
+func (t *TCKeccak256Bytes) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasKeccak256Bytes(eng, index, args)
+}
+
+// c: char *TC_Keccak256Bytes(void *data, int len)
+func tcKeccak256Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return hashBytes(eng, args, sha3.NewLegacyKeccak256())
+}
+
+type TCSha256Bytes struct{}
+
+func (t *TCSha256Bytes) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcSha256Bytes(eng, index, args)
+}
+func (t *TCSha256Bytes) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasSha256Bytes(eng, index, args)
+}
+
+// c: char *TC_Sha256Bytes(void *data, int len)
+func tcSha256Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return hashBytes(eng, args, sha256.New())
+}
+
+type TCRipemd160Bytes struct{}
+
+func (t *TCRipemd160Bytes) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcRipemd160Bytes(eng, index, args)
+}
+func (t *TCRipemd160Bytes) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasRipemd160Bytes(eng, index, args)
+}
+
+// c: char *TC_Ripemd160Bytes(void *data, int len)
+func tcRipemd160Bytes(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return hashBytes(eng, args, ripemd160.New())
+}
+
+type TCSha3_256 struct{}
+
+func (t *TCSha3_256) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcSha3_256(eng, index, args)
+}
+func (t *TCSha3_256) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasSha3_256(eng, index, args)
+}
+
+// TC_Sha3_256 is the FIPS 202 SHA3-256, which pads differently from the
+// Keccak256 of TC_Keccak256.
+// c: char *TC_Sha3_256(void *data, int len)
+func tcSha3_256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return hashBytes(eng, args, sha3.New256())
+}
+
+type TCSha512 struct{}
+
+func (t *TCSha512) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcSha512(eng, index, args)
+}
+func (t *TCSha512) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasSha512(eng, index, args)
+}
+
+// c: char *TC_Sha512(void *data, int len)
+func tcSha512(eng *Engine, index int64, args []uint64) (uint64, error) {
+	return hashBytes(eng, args, sha512.New())
+}
+
+type TCBlake2b256 struct{}
+
+func (t *TCBlake2b256) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBlake2b256(eng, index, args)
+}
+func (t *TCBlake2b256) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBlake2b256(eng, index, args)
+}
+
+// c: char *TC_Blake2b256(void *data, int len)
+func tcBlake2b256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	h, _ := blake2b.New256(nil)
+	return hashBytes(eng, args, h)
+}
+
+type TCBlake2s256 struct{}
+
+func (t *TCBlake2s256) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcBlake2s256(eng, index, args)
+}
+func (t *TCBlake2s256) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasBlake2s256(eng, index, args)
+}
+
+// c: char *TC_Blake2s256(void *data, int len)
+func tcBlake2s256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	h, _ := blake2s.New256(nil)
+	return hashBytes(eng, args, h)
+}
+
+type TCHmacSha256 struct{}
+
+func (t *TCHmacSha256) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcHmacSha256(eng, index, args)
+}
+func (t *TCHmacSha256) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasHmacSha256(eng, index, args)
+}
+
+// TC_HmacSha256 returns the HMAC-SHA256 of data(args[0], args[1]) with the key
+// of args[3] bytes at args[2].
+// c: char *TC_HmacSha256(void *data, int len, void *key, int keyLen)
+func tcHmacSha256(eng *Engine, index int64, args []uint64) (uint64, error) {
+	key, err := hashBytesArg(eng, args, 3)
+	if err != nil {
+		return 0, err
+	}
+	return hashBytes(eng, args, hmac.New(sha256.New, key))
+}
@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"testing"
+)
+
+func TestHashBytes(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	data := []byte("ab\x00cd")
+	p, err := vmem.SetBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, key := cstr(""), cstr("key")
+	str := func(p uint64) string {
+		s, _ := vmem.GetString(p)
+		return string(s)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(*Engine, int64, []uint64) (uint64, error)
+		args []uint64
+		want string
+	}{
+		{"keccak256", tcKeccak256Bytes, []uint64{empty, 0}, "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"sha256", tcSha256Bytes, []uint64{p, 5}, "0x1bd95cf6379b94fd3b6ceb1390b70b822c76442c4bfb8273b941e09d8dfd9b56"},
+		{"ripemd160", tcRipemd160Bytes, []uint64{p, 5}, "0x2acfff79c2d4ae319f217d840fb20b38e8d51794"},
+		{"sha3-256", tcSha3_256, []uint64{empty, 0}, "0xa7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+		{"sha3-256", tcSha3_256, []uint64{p, 5}, "0xf591b36a53ae69b93381141d6f66fbe2badca980d4e6f19089a2a2be2d050afd"},
+		{"sha512", tcSha512, []uint64{p, 5}, "0x6f74b9701ed4220859f879533dbb4b6602dc381acabc47353ee26d09413057c2ffbc4be94f5be09ab3a3fe78ee875035f904fe4a5fad3807c5928819e97be9e7"},
+		{"blake2b-256", tcBlake2b256, []uint64{p, 5}, "0xb6a5013e624fdff600cc792368b528865485407e6bd06c4d4a2837d1cd9ab09f"},
+		{"blake2s-256", tcBlake2s256, []uint64{p, 5}, "0x416a6b55c80b6c02a728654c880d0456c03f1164350a5430230eae1bb8234202"},
+		{"hmac-sha256", tcHmacSha256, []uint64{p, 5, key, 3}, "0x7a2d134a4f0fe2a70469c715a6bad9309f42c257be8b71fb7703d356c262f1b3"},
+	}
+	for _, test := range tests {
+		ret, err := test.fn(eng, -1, test.args)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := str(ret); got != test.want {
+			t.Errorf("%s: got %s want %s", test.name, got, test.want)
+		}
+	}
+
+	// the string variant stops at the zero byte
+	ret, _ := tcSha256(eng, -1, []uint64{p})
+	if ret2, _ := tcSha256Bytes(eng, -1, []uint64{p, 2}); str(ret) != str(ret2) {
+		t.Errorf("TC_Sha256 %s != TC_Sha256Bytes of 2 bytes %s", str(ret), str(ret2))
+	}
+	if _, err := tcSha512(eng, -1, []uint64{p, 1<<32 - 1}); err != ErrInvalidApiArgs {
+		t.Errorf("negative length: %v", err)
+	}
+
+	if gas, _ := new(TCSha256Bytes).Gas(-1, eng, []uint64{p, 33}); gas != GasExtStep+HashSetGas+2*Sha256PerWordGas {
+		t.Errorf("sha256 gas %d", gas)
+	}
+	if gas, _ := gasHmacSha256(eng, -1, []uint64{p, 30, key, 3}); gas != GasExtStep+HashSetGas+6*Sha256PerWordGas {
+		t.Errorf("hmac-sha256 gas %d", gas)
+	}
+}
@@ -0,0 +1,52 @@
+package vm
+
+// Frame is a snapshot of the innermost wasm function executed by an APP
+// running on the interpreter.
+type Frame struct {
+	Func   int64
+	Name   string
+	PC     int64
+	Stack  []uint64
+	Locals []uint64
+}
+
+// interpFrame returns the function index and the pc of the instruction app
+// executes on the interpreter, ok is false if it runs no code.
+func (app *APP) interpFrame() (fnIndex int64, pc int64, ok bool) {
+	if app.VM == nil {
+		return 0, 0, false
+	}
+	return app.VM.CurrentFrame()
+}
+
+// CurrentFrame returns the innermost interpreter frame of app, or nil if the
+// app runs natively or runs no code.
+func (app *APP) CurrentFrame() *Frame {
+	if app.runsNative() {
+		return nil
+	}
+	fnIndex, pc, ok := app.interpFrame()
+	if !ok {
+		return nil
+	}
+
+	stack, locals := app.VM.FrameValues()
+	f := &Frame{
+		Func:   fnIndex,
+		PC:     pc,
+		Stack:  append([]uint64(nil), stack...),
+		Locals: append([]uint64(nil), locals...),
+	}
+	if fn := app.Module.GetFunction(int(f.Func)); fn != nil {
+		f.Name = fn.Name
+	}
+	return f
+}
+
+// Globals returns a copy of the wasm globals of app.
+func (app *APP) Globals() []uint64 {
+	if app.VM == nil {
+		return nil
+	}
+	return append([]uint64(nil), app.VM.Globals()...)
+}
@@ -0,0 +1,341 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The values of the TC_Json api are held in a handle table of the running
+// frame: a contract can't use the handles of its caller or callee, and the
+// table is freed when the frame returns. Handles are opaque, non zero, and
+// checked for their slot, kind and generation, so a freed or forged handle
+// fails instead of reading another value: a slot whose generation would wrap
+// around is retired instead of reused, so no handle comes back within a
+// frame. The bytes held by the tables of a transaction are limited to
+// MaxJsonMemory; they are charged MemoryGas per word when the values are
+// parsed or put.
+//
+// Handles used to be indices in one slice of the transaction, from 0. The
+// change of values is not gated by height: a handle is a void * of the C api
+// that contracts pass back to it, it reaches no state, log or return data by
+// itself, and a failed parse aborts the call in both versions, so contracts
+// could not even compare handles to NULL. Runs only differ where a contract
+// used a handle of another frame, which now fails instead of reading the
+// values of its caller or callee.
+
+const (
+	jsonObject uint32 = 1
+	jsonArray  uint32 = 2
+
+	// handle: 0x40000000 | kind<<28 | gen<<20 | slot+1
+	jsonHandleTag  = 0x40000000
+	jsonKindShift  = 28
+	jsonGenShift   = 20
+	jsonSlotMask   = 1<<jsonGenShift - 1
+	jsonGenMask    = 0xff
+	jsonEntrySize  = 32 // bytes accounted per handle
+	jsonHandleMask = jsonHandleTag | 3<<jsonKindShift | jsonGenMask<<jsonGenShift | jsonSlotMask
+)
+
+type jsonEntry struct {
+	kind uint32 // 0 if free
+	gen  uint32
+	obj  map[string]json.RawMessage
+	arr  []json.RawMessage
+	size int
+}
+
+// jsonTable holds the JSON values of a frame.
+type jsonTable struct {
+	entries []jsonEntry
+	free    []int
+	size    int
+}
+
+func (t *jsonTable) handle(slot int) uint64 {
+	e := &t.entries[slot]
+	return uint64(jsonHandleTag | e.kind<<jsonKindShift | e.gen<<jsonGenShift | uint32(slot+1))
+}
+
+// jsonTable returns the table of the running frame.
+func (eng *Engine) jsonTable() *jsonTable {
+	app, _ := eng.RunningAppFrame()
+	if app.json == nil {
+		app.json = new(jsonTable)
+	}
+	return app.json
+}
+
+// useJSONMemory accounts n more bytes of handles in the transaction.
+func (eng *Engine) useJSONMemory(t *jsonTable, n int) error {
+	if eng.jsonMemory+n > MaxJsonMemory {
+		return ErrJSONMemory
+	}
+	eng.jsonMemory += n
+	t.size += n
+	return nil
+}
+
+func (eng *Engine) newJSON(kind uint32, obj map[string]json.RawMessage, arr []json.RawMessage, size int) (uint64, error) {
+	t := eng.jsonTable()
+	size += jsonEntrySize
+	var slot int
+	if n := len(t.free); n > 0 {
+		slot = t.free[n-1]
+		if err := eng.useJSONMemory(t, size); err != nil {
+			return 0, err
+		}
+		t.free = t.free[:n-1]
+	} else {
+		if len(t.entries) >= jsonSlotMask {
+			return 0, ErrJSONMemory
+		}
+		if err := eng.useJSONMemory(t, size); err != nil {
+			return 0, err
+		}
+		slot = len(t.entries)
+		t.entries = append(t.entries, jsonEntry{})
+	}
+
+	e := &t.entries[slot]
+	e.kind, e.obj, e.arr, e.size = kind, obj, arr, size
+	return t.handle(slot), nil
+}
+
+// newJSONObject returns a handle to obj, parsed from size bytes.
+func (eng *Engine) newJSONObject(obj map[string]json.RawMessage, size int) (uint64, error) {
+	return eng.newJSON(jsonObject, obj, nil, size)
+}
+
+// newJSONArray returns a handle to arr, parsed from size bytes.
+func (eng *Engine) newJSONArray(arr []json.RawMessage, size int) (uint64, error) {
+	if arr == nil {
+		arr = make([]json.RawMessage, 0)
+	}
+	return eng.newJSON(jsonArray, nil, arr, size)
+}
+
+func (eng *Engine) jsonEntry(h uint64, kind uint32) (*jsonEntry, error) {
+	if h&^jsonHandleMask != 0 || h&jsonHandleTag == 0 {
+		return nil, ErrJSONHandle
+	}
+	slot := int(h&jsonSlotMask) - 1
+	t := eng.jsonTable()
+	if slot < 0 || slot >= len(t.entries) {
+		return nil, ErrJSONHandle
+	}
+	e := &t.entries[slot]
+	if e.kind == 0 || t.handle(slot) != h || (kind != 0 && e.kind != kind) {
+		return nil, ErrJSONHandle
+	}
+	return e, nil
+}
+
+// jsonObject returns the object of handle h.
+func (eng *Engine) jsonObject(h uint64) (map[string]json.RawMessage, error) {
+	e, err := eng.jsonEntry(h, jsonObject)
+	if err != nil {
+		return nil, err
+	}
+	return e.obj, nil
+}
+
+// jsonArray returns the array of handle h.
+func (eng *Engine) jsonArray(h uint64) ([]json.RawMessage, error) {
+	e, err := eng.jsonEntry(h, jsonArray)
+	if err != nil {
+		return nil, err
+	}
+	return e.arr, nil
+}
+
+// jsonPut sets key of the object of handle h to v.
+func (eng *Engine) jsonPut(h uint64, key string, v json.RawMessage) error {
+	e, err := eng.jsonEntry(h, jsonObject)
+	if err != nil {
+		return err
+	}
+	if _, ok := e.obj[key]; !ok {
+		if err := eng.useJSONMemory(eng.jsonTable(), len(key)+len(v)); err != nil {
+			return err
+		}
+		e.size += len(key) + len(v)
+	} else if n := len(v) - len(e.obj[key]); n > 0 {
+		if err := eng.useJSONMemory(eng.jsonTable(), n); err != nil {
+			return err
+		}
+		e.size += n
+	}
+	e.obj[key] = v
+	return nil
+}
+
+// jsonPush appends v to the array of handle h.
+func (eng *Engine) jsonPush(h uint64, v json.RawMessage) error {
+	e, err := eng.jsonEntry(h, jsonArray)
+	if err != nil {
+		return err
+	}
+	if err := eng.useJSONMemory(eng.jsonTable(), len(v)); err != nil {
+		return err
+	}
+	e.size += len(v)
+	e.arr = append(e.arr, v)
+	return nil
+}
+
+// A key of the TC_JsonGet api is either a key of the root object or a path
+// of keys and array indexes through nested values, as in "a.b[2].c". The
+// key of the root is tried first, so keys holding '.' or '[' still read.
+type jsonStep struct {
+	key   string
+	index int // -1 for a key
+}
+
+func parseJSONPath(path string) ([]jsonStep, error) {
+	var steps []jsonStep
+	for _, part := range strings.Split(path, ".") {
+		i := strings.IndexByte(part, '[')
+		if i < 0 {
+			i = len(part)
+		}
+		if i == 0 {
+			return nil, ErrJSONPath
+		}
+		steps = append(steps, jsonStep{key: part[:i], index: -1})
+		for part = part[i:]; len(part) > 0; {
+			end := strings.IndexByte(part, ']')
+			if part[0] != '[' || end < 2 {
+				return nil, ErrJSONPath
+			}
+			n, err := strconv.ParseUint(part[1:end], 10, 31)
+			if err != nil {
+				return nil, ErrJSONPath
+			}
+			steps = append(steps, jsonStep{index: int(n)})
+			part = part[end+1:]
+		}
+	}
+	return steps, nil
+}
+
+// jsonLookupResult is a path walked by the Gas of a host function, kept in
+// APP.result for its Call.
+type jsonLookupResult struct {
+	h       uint64
+	path    string
+	v       json.RawMessage
+	decoded int
+}
+
+// jsonLookup returns the value at path in the object of handle h, nil if
+// there is none, and the bytes of the nested values decoded to reach it. It
+// takes the result of the Gas of the running host function if it walked the
+// same path.
+func (eng *Engine) jsonLookup(h uint64, path string) (json.RawMessage, int, error) {
+	app, _ := eng.RunningAppFrame()
+	if r, ok := app.result.(*jsonLookupResult); ok {
+		app.result = nil
+		if r.h == h && r.path == path {
+			return r.v, r.decoded, nil
+		}
+	}
+	return eng.jsonWalk(h, path)
+}
+
+// jsonWalk is jsonLookup walking path.
+func (eng *Engine) jsonWalk(h uint64, path string) (json.RawMessage, int, error) {
+	obj, err := eng.jsonObject(h)
+	if err != nil {
+		return nil, 0, err
+	}
+	if v, ok := obj[path]; ok || !strings.ContainsAny(path, ".[") {
+		return v, 0, nil
+	}
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v, decoded := obj[steps[0].key], 0
+	for _, step := range steps[1:] {
+		if len(v) == 0 {
+			return nil, decoded, nil
+		}
+		decoded += len(v)
+		if step.index < 0 {
+			var m map[string]json.RawMessage
+			if json.Unmarshal(v, &m) != nil {
+				return nil, decoded, nil
+			}
+			v = m[step.key]
+		} else {
+			var a []json.RawMessage
+			if json.Unmarshal(v, &a) != nil || step.index >= len(a) {
+				return nil, decoded, nil
+			}
+			v = a[step.index]
+		}
+	}
+	return v, decoded, nil
+}
+
+// jsonGet is jsonLookup failing on a missing value.
+func (eng *Engine) jsonGet(h uint64, path string) (json.RawMessage, error) {
+	v, _, err := eng.jsonLookup(h, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, fmt.Errorf("key(%s) not exist", path)
+	}
+	return v, nil
+}
+
+// freeJSON frees the value of handle h, of any kind.
+func (eng *Engine) freeJSON(h uint64) error {
+	e, err := eng.jsonEntry(h, 0)
+	if err != nil {
+		return err
+	}
+	t := eng.jsonTable()
+	slot := int(h&jsonSlotMask) - 1
+	eng.jsonMemory -= e.size
+	t.size -= e.size
+	if e.gen == jsonGenMask {
+		// retired, its handles would come back
+		*e = jsonEntry{gen: e.gen}
+		return nil
+	}
+	*e = jsonEntry{gen: e.gen + 1}
+	t.free = append(t.free, slot)
+	return nil
+}
+
+// releaseJSON frees the table of app when its frame returns.
+func (eng *Engine) releaseJSON(app *APP) {
+	if app.json != nil {
+		eng.jsonMemory -= app.json.size
+		app.json = nil
+	}
+}
+
+// jsonValues returns the live objects and arrays of the table of app.
+func (app *APP) jsonValues() ([]map[string]json.RawMessage, [][]json.RawMessage) {
+	if app.json == nil {
+		return nil, nil
+	}
+	var objs []map[string]json.RawMessage
+	var arrs [][]json.RawMessage
+	for _, e := range app.json.entries {
+		switch e.kind {
+		case jsonObject:
+			objs = append(objs, e.obj)
+		case jsonArray:
+			arrs = append(arrs, e.arr)
+		}
+	}
+	return objs, arrs
+}
@@ -0,0 +1,313 @@
+package vm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/state"
+	"github.com/xunleichain/tc-wasm/mock/types"
+)
+
+// jsonTestEngine returns an Engine running malloc.wasm, to call the json api
+// on directly, and a function copying C strings into its memory.
+func jsonTestEngine(t *testing.T) (*Engine, func(s string) uint64) {
+	code, err := ioutil.ReadFile("../testdata/malloc.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := state.New()
+	addr := types.BytesToAddress([]byte{1})
+	contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+	eng := NewEngine(contract, 1000000, db, log.Test())
+	app, err := eng.NewApp(addr.String(), code, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eng.runningFrame = app
+
+	cstr := func(s string) uint64 {
+		p, err := app.VM.VMemory().SetBytes([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	return eng, cstr
+}
+
+func TestJSONArray(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+
+	data := `[{"to":"a","value":1},{"to":"b","value":"0x2"},"c",4]`
+	arr, err := tcJSONParseArray(eng, -1, []uint64{cstr(data)})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if n, _ := tcJSONArrayLen(eng, -1, []uint64{arr}); n != 4 {
+		t.Fatalf("len %d", n)
+	}
+	obj, err := tcJSONArrayGetObject(eng, -1, []uint64{arr, 1})
+	if err != nil {
+		t.Fatalf("get object: %v", err)
+	}
+	if v, err := tcJSONGetInt(eng, -1, []uint64{obj, cstr("value")}); err != nil || v != 2 {
+		t.Fatalf("value %d, %v", v, err)
+	}
+	p, err := tcJSONArrayGetString(eng, -1, []uint64{arr, 2})
+	if err != nil {
+		t.Fatalf("get string: %v", err)
+	}
+	if s, _ := app.VM.VMemory().GetString(p); string(s) != "c" {
+		t.Fatalf("string %q", s)
+	}
+	if v, err := tcJSONArrayGetInt64(eng, -1, []uint64{arr, 3}); err != nil || v != 4 {
+		t.Fatalf("int64 %d, %v", v, err)
+	}
+	for _, i := range []uint64{4, uint64(0xffffffff)} {
+		if _, err := tcJSONArrayGetInt(eng, -1, []uint64{arr, i}); err == nil {
+			t.Fatalf("index %d read", int32(i))
+		}
+	}
+	if _, err := tcJSONParseArray(eng, -1, []uint64{cstr(`{"a":1}`)}); err == nil {
+		t.Fatalf("object parsed as array")
+	}
+
+	root, _ := tcJSONParse(eng, -1, []uint64{cstr(`{"to":["x","y"],"n":1}`)})
+	to, err := tcJSONGetArray(eng, -1, []uint64{root, cstr("to")})
+	if err != nil {
+		t.Fatalf("get array: %v", err)
+	}
+	if n, _ := tcJSONArrayLen(eng, -1, []uint64{to}); n != 2 {
+		t.Fatalf("len %d", n)
+	}
+	if _, err := tcJSONGetArray(eng, -1, []uint64{root, cstr("n")}); err == nil {
+		t.Fatalf("number read as array")
+	}
+
+	out, _ := tcJSONNewArray(eng, -1, nil)
+	tcJSONArrayPushInt(eng, -1, []uint64{out, uint64(0xffffffff)})
+	tcJSONArrayPushInt64(eng, -1, []uint64{out, 1 << 40})
+	tcJSONArrayPushString(eng, -1, []uint64{out, cstr("s\"")})
+	tcJSONArrayPushObject(eng, -1, []uint64{out, obj})
+	res, _ := tcJSONNewObject(eng, -1, nil)
+	if _, err := tcJSONPutArray(eng, -1, []uint64{res, cstr("list"), out}); err != nil {
+		t.Fatalf("put array: %v", err)
+	}
+	v, _ := eng.jsonObject(res)
+	got, _ := json.Marshal(v)
+	want := `{"list":[-1,1099511627776,"s\"",{"to":"b","value":"0x2"}]}`
+	if string(got) != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestJSONArrayGas(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+
+	small, err := gasJSONParseArray(eng, -1, []uint64{cstr(`[1]`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := gasJSONParseArray(eng, -1, []uint64{cstr(`[` + strings.Repeat(`1,`, 128) + `1]`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if large <= small {
+		t.Fatalf("gas not proportional to the data: %d <= %d", large, small)
+	}
+
+	arr, _ := tcJSONParseArray(eng, -1, []uint64{cstr(`["a","` + strings.Repeat("a", 256) + `"]`)})
+	g0, _ := gasJSONArrayGetString(eng, -1, []uint64{arr, 0})
+	g1, _ := gasJSONArrayGetString(eng, -1, []uint64{arr, 1})
+	if g1 <= g0 {
+		t.Fatalf("gas not proportional to the element: %d <= %d", g1, g0)
+	}
+	if _, err := gasJSONArrayGetString(eng, -1, []uint64{arr, 2}); err == nil {
+		t.Fatalf("gas of an index out of range")
+	}
+}
+
+func TestJSONHandles(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+
+	root, err := tcJSONParse(eng, -1, []uint64{cstr(`{"a":1}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	arr, _ := tcJSONNewArray(eng, -1, nil)
+	key := cstr("a")
+	for _, h := range []uint64{0, 1, root + 1, root | 1<<32, arr} {
+		if _, err := tcJSONGetInt(eng, -1, []uint64{h, key}); err != ErrJSONHandle {
+			t.Fatalf("handle %#x used as object: %v", h, err)
+		}
+	}
+
+	used := eng.jsonMemory
+	if err := eng.freeJSON(root); err != nil {
+		t.Fatalf("free: %v", err)
+	}
+	if eng.jsonMemory >= used {
+		t.Fatalf("memory not released: %d >= %d", eng.jsonMemory, used)
+	}
+	if _, err := tcJSONGetInt(eng, -1, []uint64{root, key}); err != ErrJSONHandle {
+		t.Fatalf("freed handle used: %v", err)
+	}
+	if err := eng.freeJSON(root); err != ErrJSONHandle {
+		t.Fatalf("handle freed twice: %v", err)
+	}
+	again, _ := tcJSONParse(eng, -1, []uint64{cstr(`{"a":2}`)})
+	if again == root {
+		t.Fatalf("freed handle reused")
+	}
+
+	// a slot is retired before its generation wraps around
+	seen := map[uint64]bool{again: true}
+	for i := 0; i < 2*(jsonGenMask+1); i++ {
+		if err := eng.freeJSON(again); err != nil {
+			t.Fatalf("free: %v", err)
+		}
+		if again, err = tcJSONParse(eng, -1, []uint64{cstr(`{"a":2}`)}); err != nil {
+			t.Fatal(err)
+		}
+		if seen[again] {
+			t.Fatalf("handle %#x reused after %d frees", again, i+1)
+		}
+		seen[again] = true
+	}
+
+	// the handles of a frame are not seen by another one
+	callee, err := eng.NewApp(app.Name, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eng.runningFrame = callee
+	if _, err := eng.jsonObject(again); err != ErrJSONHandle {
+		t.Fatalf("handle of the caller used: %v", err)
+	}
+	eng.runningFrame = app
+
+	eng.releaseJSON(app)
+	if eng.jsonMemory != 0 || app.json != nil {
+		t.Fatalf("frame memory not released: %d", eng.jsonMemory)
+	}
+
+	eng.jsonMemory = MaxJsonMemory - 16
+	if _, err := tcJSONParse(eng, -1, []uint64{cstr(`{"a":1}`)}); err != ErrJSONMemory {
+		t.Fatalf("memory limit not enforced: %v", err)
+	}
+	if _, err := tcJSONNewArray(eng, -1, nil); err != ErrJSONMemory {
+		t.Fatalf("memory limit not enforced: %v", err)
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+
+	data := `{"a":{"b":[1,{"c":"0x10"},{"d":true}],"n":null},"x.y":7,"ok":"false"}`
+	root, err := tcJSONParse(eng, -1, []uint64{cstr(data)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]uint64{"a.b[1].c": 16, "a.b[0]": 1, "x.y": 7} {
+		if v, err := tcJSONGetInt(eng, -1, []uint64{root, cstr(path)}); err != nil || v != want {
+			t.Fatalf("%s: %d, %v", path, v, err)
+		}
+	}
+	for path, want := range map[string]uint64{"a": 1, "a.b[2].d": 1, "a.n": 1, "a.b[3]": 0, "a.b.c": 0, "ok.c": 0, "z": 0} {
+		if v, err := tcJSONHas(eng, -1, []uint64{root, cstr(path)}); err != nil || v != want {
+			t.Fatalf("has %s: %d, %v", path, v, err)
+		}
+	}
+	for _, path := range []string{"a..b", "a.b[", "a.b[x]", "a.b[-1]", "[0]", "a.b]0["} {
+		if _, err := tcJSONHas(eng, -1, []uint64{root, cstr(path)}); err != ErrJSONPath {
+			t.Fatalf("path %q: %v", path, err)
+		}
+	}
+	if _, err := tcJSONGetInt(eng, -1, []uint64{root, cstr("a.b[9]")}); err == nil {
+		t.Fatalf("missing path read")
+	}
+
+	if v, err := tcJSONGetBool(eng, -1, []uint64{root, cstr("a.b[2].d")}); err != nil || v != 1 {
+		t.Fatalf("bool %d, %v", v, err)
+	}
+	if v, err := tcJSONGetBool(eng, -1, []uint64{root, cstr("ok")}); err != nil || v != 0 {
+		t.Fatalf("quoted bool %d, %v", v, err)
+	}
+	if _, err := tcJSONGetBool(eng, -1, []uint64{root, cstr("x.y")}); err == nil {
+		t.Fatalf("int read as bool")
+	}
+	if v, _ := tcJSONIsNull(eng, -1, []uint64{root, cstr("a.n")}); v != 1 {
+		t.Fatalf("null not seen")
+	}
+	if v, _ := tcJSONIsNull(eng, -1, []uint64{root, cstr("a.m")}); v != 0 {
+		t.Fatalf("missing key seen as null")
+	}
+
+	if v, err := tcJSONGetIntOr(eng, -1, []uint64{root, cstr("a.m"), 5}); err != nil || v != 5 {
+		t.Fatalf("int default %d, %v", v, err)
+	}
+	if v, err := tcJSONGetInt64Or(eng, -1, []uint64{root, cstr("a.n"), 6}); err != nil || v != 6 {
+		t.Fatalf("int64 default %d, %v", v, err)
+	}
+	if v, err := tcJSONGetInt64Or(eng, -1, []uint64{root, cstr("a.b[1].c"), 6}); err != nil || v != 16 {
+		t.Fatalf("int64 %d, %v", v, err)
+	}
+	if v, err := tcJSONGetBoolOr(eng, -1, []uint64{root, cstr("a.b[9]"), 1}); err != nil || v != 1 {
+		t.Fatalf("bool default %d, %v", v, err)
+	}
+	def := cstr("def")
+	if p, err := tcJSONGetStringOr(eng, -1, []uint64{root, cstr("a.z"), def}); err != nil || p != def {
+		t.Fatalf("string default %d, %v", p, err)
+	}
+	p, err := tcJSONGetStringOr(eng, -1, []uint64{root, cstr("a.b[1].c"), def})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := app.VM.VMemory().GetString(p); string(s) != "0x10" {
+		t.Fatalf("string %q", s)
+	}
+	if _, err := tcJSONGetStringOr(eng, -1, []uint64{root, cstr("x.y"), def}); err == nil {
+		t.Fatalf("int read as string")
+	}
+
+	obj, err := tcJSONGetObject(eng, -1, []uint64{root, cstr("a.b[1]")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := tcJSONGetInt(eng, -1, []uint64{obj, cstr("c")}); err != nil || v != 16 {
+		t.Fatalf("object %d, %v", v, err)
+	}
+
+	// a path is charged for the nested values it decodes
+	top, _ := gasJSONGetInt(eng, -1, []uint64{root, cstr("x.y")})
+	deep, _ := gasJSONGetInt(eng, -1, []uint64{root, cstr("a.b[1].c")})
+	if top != GasExtStep || deep <= top {
+		t.Fatalf("gas %d, %d", top, deep)
+	}
+
+	// the call takes the value of the path walked by the gas
+	args := []uint64{root, cstr("a.b[1].c")}
+	if _, err := gasJSONGetInt(eng, -1, args); err != nil {
+		t.Fatal(err)
+	}
+	r, ok := app.result.(*jsonLookupResult)
+	if !ok || string(r.v) != `"0x10"` {
+		t.Fatalf("path not kept for the call: %#v", app.result)
+	}
+	r.v = json.RawMessage("17")
+	if v, err := tcJSONGetInt(eng, -1, args); err != nil || v != 17 || app.result != nil {
+		t.Fatalf("call walked the path again: %d, %v", v, err)
+	}
+	// and walks it if the gas walked another one
+	gasJSONGetInt(eng, -1, []uint64{root, cstr("x.y")})
+	if v, err := tcJSONGetInt(eng, -1, args); err != nil || v != 16 {
+		t.Fatalf("call took the value of another path: %d, %v", v, err)
+	}
+}
@@ -0,0 +1,148 @@
+package vm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// go merkle proof api
+//
+// The root, the leaf and the proof are 0x prefixed hex strings as returned
+// by the hash api: the leaf is the hash of the leaf data, and the proof the
+// concatenation of the 32 bytes sibling hashes from the leaf level up.
+
+const (
+	merkleKeccak256 = 0
+	merkleSha256    = 1
+)
+
+func merkleHash(alg uint64) (hash.Hash, error) {
+	switch alg {
+	case merkleKeccak256:
+		return sha3.NewLegacyKeccak256(), nil
+	case merkleSha256:
+		return sha256.New(), nil
+	}
+	return nil, ErrMerkleHashAlg
+}
+
+func merkleHex(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrMerkleProof
+	}
+	return b, nil
+}
+
+// merkleArgs returns the hash of args[3], the root, the leaf and the
+// siblings of the proof.
+func merkleArgs(eng *Engine, args []uint64) (h hash.Hash, root, leaf []byte, proof [][]byte, err error) {
+	if h, err = merkleHash(args[3]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	runningFrame, _ := eng.RunningAppFrame()
+	vmem := runningFrame.VM.VMemory()
+	var nodes [3][]byte
+	for i := range nodes {
+		s, err := vmem.GetString(args[i])
+		if err != nil {
+			return nil, nil, nil, nil, ErrInvalidApiArgs
+		}
+		if nodes[i], err = merkleHex(string(s)); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+	root, leaf = nodes[0], nodes[1]
+	if len(root) != 32 || len(leaf) != 32 || len(nodes[2])%32 != 0 {
+		return nil, nil, nil, nil, ErrMerkleProof
+	}
+	for i := 0; i < len(nodes[2]); i += 32 {
+		proof = append(proof, nodes[2][i:i+32])
+	}
+	return h, root, leaf, proof, nil
+}
+
+func merkleParent(h hash.Hash, left, right []byte) []byte {
+	h.Reset()
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+type TCVerifyMerkleProof struct{}
+
+func (t *TCVerifyMerkleProof) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcVerifyMerkleProof(eng, index, args)
+}
+func (t *TCVerifyMerkleProof) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasVerifyMerkleProof(eng, index, args)
+}
+
+// TC_VerifyMerkleProof returns 1 if proof leads from leaf to root, 0 if not.
+// The two nodes of each pair are hashed in ascending order, so the proof
+// needs no positions.
+// c: int TC_VerifyMerkleProof(char *root, char *leaf, char *proof, int hashAlg)
+func tcVerifyMerkleProof(eng *Engine, index int64, args []uint64) (uint64, error) {
+	h, root, node, proof, err := merkleArgs(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	for _, sibling := range proof {
+		if bytes.Compare(node, sibling) <= 0 {
+			node = merkleParent(h, node, sibling)
+		} else {
+			node = merkleParent(h, sibling, node)
+		}
+	}
+	if bytes.Equal(node, root) {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+type TCVerifyMerkleProofAt struct{}
+
+func (t *TCVerifyMerkleProofAt) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return tcVerifyMerkleProofAt(eng, index, args)
+}
+func (t *TCVerifyMerkleProofAt) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	return gasVerifyMerkleProof(eng, index, args)
+}
+
+// TC_VerifyMerkleProofAt returns 1 if proof leads from the leaf at position
+// pos to root, 0 if not. Bit i of pos is set if the node at level i is a
+// right child.
+// c: int TC_VerifyMerkleProofAt(char *root, char *leaf, char *proof, int hashAlg, int64 pos)
+func tcVerifyMerkleProofAt(eng *Engine, index int64, args []uint64) (uint64, error) {
+	h, root, node, proof, err := merkleArgs(eng, args)
+	if err != nil {
+		return 0, err
+	}
+	pos := args[4]
+	if len(proof) < 64 && pos>>uint(len(proof)) != 0 {
+		return 0, nil
+	}
+	for i, sibling := range proof {
+		if i < 64 && pos&(1<<uint(i)) != 0 {
+			node = merkleParent(h, sibling, node)
+		} else {
+			node = merkleParent(h, node, sibling)
+		}
+	}
+	if bytes.Equal(node, root) {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,83 @@
+package vm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestVerifyMerkleProof(t *testing.T) {
+	eng, cstr := jsonTestEngine(t)
+	keccak := func(b ...[]byte) []byte {
+		h := sha3.NewLegacyKeccak256()
+		for _, p := range b {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+	sha := func(b ...[]byte) []byte {
+		h := sha256.New()
+		for _, p := range b {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+	sorted := func(a, b []byte) []byte {
+		if bytes.Compare(a, b) > 0 {
+			a, b = b, a
+		}
+		return keccak(a, b)
+	}
+	hexArg := func(b ...[]byte) uint64 {
+		return cstr(fmt.Sprintf("0x%x", bytes.Join(b, nil)))
+	}
+
+	var leaves [4][]byte
+	for i := range leaves {
+		leaves[i] = keccak([]byte{byte(i)})
+	}
+	sortedRoot := sorted(sorted(leaves[0], leaves[1]), sorted(leaves[2], leaves[3]))
+	l01, l23 := sha(leaves[0], leaves[1]), sha(leaves[2], leaves[3])
+	posRoot := sha(l01, l23)
+
+	tests := []struct {
+		fn   func(*Engine, int64, []uint64) (uint64, error)
+		args []uint64
+		want uint64
+	}{
+		{tcVerifyMerkleProof, []uint64{hexArg(sortedRoot), hexArg(leaves[2]), hexArg(leaves[3], sorted(leaves[0], leaves[1])), merkleKeccak256}, 1},
+		{tcVerifyMerkleProof, []uint64{hexArg(sortedRoot), hexArg(leaves[1]), hexArg(leaves[0], sorted(leaves[2], leaves[3])), merkleKeccak256}, 1},
+		{tcVerifyMerkleProof, []uint64{hexArg(sortedRoot), hexArg(leaves[1]), hexArg(leaves[0], sorted(leaves[2], leaves[3])), merkleSha256}, 0},
+		{tcVerifyMerkleProof, []uint64{hexArg(sortedRoot), hexArg(leaves[1]), hexArg(leaves[2], sorted(leaves[0], leaves[3])), merkleKeccak256}, 0},
+		{tcVerifyMerkleProof, []uint64{hexArg(sortedRoot), hexArg(sortedRoot), hexArg(), merkleKeccak256}, 1},
+		{tcVerifyMerkleProofAt, []uint64{hexArg(posRoot), hexArg(leaves[2]), hexArg(leaves[3], l01), merkleSha256, 2}, 1},
+		{tcVerifyMerkleProofAt, []uint64{hexArg(posRoot), hexArg(leaves[1]), hexArg(leaves[0], l23), merkleSha256, 1}, 1},
+		{tcVerifyMerkleProofAt, []uint64{hexArg(posRoot), hexArg(leaves[1]), hexArg(leaves[0], l23), merkleSha256, 0}, 0},
+		{tcVerifyMerkleProofAt, []uint64{hexArg(posRoot), hexArg(leaves[1]), hexArg(leaves[0], l23), merkleSha256, 5}, 0},
+	}
+	for i, test := range tests {
+		if ret, err := test.fn(eng, -1, test.args); err != nil || ret != test.want {
+			t.Errorf("%d: got %d, %v want %d", i, ret, err, test.want)
+		}
+	}
+
+	if _, err := tcVerifyMerkleProof(eng, -1, []uint64{hexArg(sortedRoot), hexArg(leaves[0]), hexArg(leaves[1]), 2}); err != ErrMerkleHashAlg {
+		t.Errorf("unknown hash: %v", err)
+	}
+	if _, err := tcVerifyMerkleProof(eng, -1, []uint64{hexArg(sortedRoot), hexArg(leaves[0]), hexArg(leaves[1][:31]), merkleKeccak256}); err != ErrMerkleProof {
+		t.Errorf("short sibling: %v", err)
+	}
+	if _, err := tcVerifyMerkleProof(eng, -1, []uint64{hexArg(sortedRoot), cstr("0xzz"), hexArg(), merkleKeccak256}); err != ErrMerkleProof {
+		t.Errorf("bad hex: %v", err)
+	}
+
+	if gas, _ := gasVerifyMerkleProof(eng, -1, []uint64{0, 0, hexArg(leaves[0], leaves[1]), merkleKeccak256}); gas != GasExtStep+2*(Sha3Gas+2*Sha3WordGas) {
+		t.Errorf("keccak256 gas %d", gas)
+	}
+	if gas, _ := gasVerifyMerkleProof(eng, -1, []uint64{0, 0, hexArg(leaves[0]), merkleSha256}); gas != GasExtStep+Sha256BaseGas+2*Sha256PerWordGas {
+		t.Errorf("sha256 gas %d", gas)
+	}
+}
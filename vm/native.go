@@ -46,6 +46,16 @@ static inline int32_t has_main_func(void *dl) {
 	return _main ? 1 : 0;
 }
 
+typedef int32_t (*tc_env_funcs_t)(const char ***);
+
+static inline int32_t get_env_funcs(void *dl, const char ***names) {
+	tc_env_funcs_t _funcs = (tc_env_funcs_t)dlsym(dl, "thunderchain_env_funcs");
+	if (_funcs == NULL) {
+		return -1;
+	}
+	return _funcs(names);
+}
+
 static uint32_t call_main(void *__ptrs) {
 	void *_ptrs[6];
 	memcpy(&_ptrs[0], __ptrs, 6 * sizeof(void *));
@@ -91,6 +101,7 @@ import (
 
 	"github.com/go-interpreter/wagon/exec"
 	"github.com/go-interpreter/wagon/memory"
+	"github.com/go-interpreter/wagon/wasm"
 	"github.com/xunleichain/tc-wasm/mock/log"
 )
 
@@ -102,34 +113,121 @@ type Native struct {
 	logger log.Logger
 	dl     *dynamicLib
 	t      time.Time
+	uses   uint64
 	ret    uint64
+
+	codeHash [32]byte // of the wasm code the library was compiled from
+
+	sandbox *SandboxConfig // run in a helper process, see sandbox.go
+	remote  *sandboxChild  // set in the helper process
 }
 
 // NewNative --
 func NewNative(app *APP, file string) (*Native, error) {
-	cfile := C.CString(file)
-	handle, err := C.dlopen(cfile, C.RTLD_LAZY|C.RTLD_LOCAL)
-	C.free(unsafe.Pointer(cfile))
-	if handle == nil {
+	return newNative(app, file, file)
+}
+
+// newNative loads the library file, opening it as path.
+func newNative(app *APP, file, path string) (*Native, error) {
+	handle, err := openNativeLib(path)
+	if err != nil {
 		app.logger.Info("[Native] C.dlopen fail", "file", file, "err", err)
-		return nil, fmt.Errorf("C.dlopen")
+		return nil, fmt.Errorf("%s %s", file, err)
 	}
 
-	native := &Native{
-		app:    app,
-		logger: app.logger,
-		t:      time.Now(),
+	hosts, err := resolveHostFuncs(handle, app.Eng.Env, hostImports(app.Module))
+	if err != nil {
+		C.dlclose(handle)
+		app.logger.Info("[Native] resolve host functions fail", "file", file, "err", err)
+		return nil, fmt.Errorf("%s %s", file, err)
 	}
 
-	if ret := C.has_main_func(handle); ret < 0 {
-		C.dlclose(handle)
-		return nil, fmt.Errorf("%s Without CMain function", file)
+	native := &Native{
+		app:      app,
+		logger:   app.logger,
+		t:        time.Now(),
+		codeHash: app.codeHash,
 	}
 
 	native.dl = newDynamicLib(file, handle, app.logger)
+	native.dl.hosts = hosts
 	return native, nil
 }
 
+// envFuncNames returns the host function table of the library, false if it
+// has none.
+func envFuncNames(so unsafe.Pointer) ([]string, bool) {
+	var cnames **C.char
+	n := int(C.get_env_funcs(so, &cnames))
+	if n < 0 {
+		return nil, false
+	}
+	if n == 0 {
+		return nil, true
+	}
+	list := (*[1 << 20]*C.char)(unsafe.Pointer(cnames))[:n:n]
+	names := make([]string, n)
+	for i, cname := range list {
+		names[i] = C.GoString(cname)
+	}
+	return names, true
+}
+
+// hostImports returns the names of the functions m imports. They come first
+// in its function index space.
+func hostImports(m *wasm.Module) []string {
+	var names []string
+	for i := 0; i < len(m.FunctionIndexSpace) && m.FunctionIndexSpace[i].IsHost(); i++ {
+		names = append(names, m.FunctionIndexSpace[i].Name)
+	}
+	return names
+}
+
+// resolveHostFuncs maps the host function table of the library to function
+// indices of env, it fails if the library has no table, if the table is not
+// the imports of the module in order, or if it lists an unknown function. The
+// generated code calls host functions by their index in the function index
+// space of the module, so GoFuncIndex never sees an index out of the table.
+func resolveHostFuncs(so unsafe.Pointer, env *EnvTable, imports []string) ([]int, error) {
+	names, ok := envFuncNames(so)
+	if !ok {
+		return nil, fmt.Errorf("No host function table")
+	}
+	if len(names) != len(imports) {
+		return nil, fmt.Errorf("Host function table of %d functions, the module imports %d", len(names), len(imports))
+	}
+	for i, name := range names {
+		if name != imports[i] {
+			return nil, fmt.Errorf("Host function %d is %s, the module imports %s", i, name, imports[i])
+		}
+	}
+	hosts := make([]int, len(names))
+	for i, name := range names {
+		index, ok := env.funcIndex(name)
+		if !ok {
+			return nil, fmt.Errorf("Unknown host function %d: %s", i, name)
+		}
+		hosts[i] = index
+	}
+	return hosts, nil
+}
+
+// openNativeLib dlopens path and checks that it has a main function.
+func openNativeLib(path string) (unsafe.Pointer, error) {
+	cfile := C.CString(path)
+	handle, err := C.dlopen(cfile, C.RTLD_LAZY|C.RTLD_LOCAL)
+	C.free(unsafe.Pointer(cfile))
+	if handle == nil {
+		return nil, fmt.Errorf("C.dlopen: %v", err)
+	}
+
+	if ret := C.has_main_func(handle); ret <= 0 {
+		C.dlclose(handle)
+		return nil, fmt.Errorf("Without CMain function")
+	}
+	return handle, nil
+}
+
 func (native *Native) close() {
 	if native != nil {
 		native.dl.free()
@@ -146,7 +244,7 @@ func (native *Native) count() uint64 {
 }
 
 func (native *Native) clone(app *APP) *Native {
-	if native == nil {
+	if native == nil || native.codeHash != app.codeHash {
 		return nil
 	}
 
@@ -157,11 +255,14 @@ func (native *Native) clone(app *APP) *Native {
 
 	t := time.Now()
 	native.t = t
+	native.uses++
 	return &Native{
-		app:    app,
-		logger: app.logger,
-		dl:     dl,
-		t:      t,
+		app:      app,
+		logger:   app.logger,
+		dl:       dl,
+		t:        t,
+		codeHash: native.codeHash,
+		sandbox:  native.sandbox,
 	}
 }
 
@@ -170,6 +271,15 @@ func (native *Native) RunCMain(action, args string) (ret uint64, err error) {
 	eng := native.engine()
 	mem := native.memory()
 
+	if native.sandbox != nil {
+		undo := native.saveRun()
+		defer func() {
+			if err == errSandboxDied {
+				undo()
+			}
+		}()
+	}
+
 	actionP, err := mem.SetBytes([]byte(action))
 	if err != nil {
 		return 0, err
@@ -208,12 +318,37 @@ func (native *Native) RunCMain(action, args string) (ret uint64, err error) {
 		}
 	}()
 
-	iret := C.call_main(unsafe.Pointer(&ptrs[0]))
+	var iret C.uint32_t
+	if native.sandbox != nil {
+		var sret uint32
+		sret, gas, gasUsed = native.runSandboxed(data)
+		iret = C.uint32_t(sret)
+	} else {
+		iret = C.call_main(unsafe.Pointer(&ptrs[0]))
+	}
 	native.updateGas(gas, gasUsed)
 	native.app.logger.Debug("[Native] RunCMain done", "app", native.name(), "ret", iret, "gas", gas, "gas_used", gasUsed)
 	return uint64(iret), nil
 }
 
+// runRemote runs the main function of the library so in a sandbox helper,
+// on the shared memory mem.
+func (native *Native) runRemote(so unsafe.Pointer, data []uint64, mem []byte) (uint32, uint64, uint64) {
+	var gas uint64
+	var gasUsed uint64
+
+	ptrs := make([]uintptr, 6)
+	ptrs[0] = uintptr(so)
+	ptrs[1] = uintptr(unsafe.Pointer(native))
+	ptrs[2] = uintptr(unsafe.Pointer(&data[0]))
+	ptrs[3] = uintptr(unsafe.Pointer(&mem[0]))
+	ptrs[4] = uintptr(unsafe.Pointer(&gasUsed))
+	ptrs[5] = uintptr(unsafe.Pointer(&gas))
+
+	iret := C.call_main(unsafe.Pointer(&ptrs[0]))
+	return uint32(iret), gas, gasUsed
+}
+
 // Printf --
 func (native *Native) Printf(f string, args ...interface{}) {
 	native.logger.Debug(fmt.Sprintf(f, args...))
@@ -271,8 +406,15 @@ func updateMem(cvm *C.vm_t, native *Native) {
 func GoPanic(cvm *C.vm_t, cmsg *C.char) {
 	native := (*Native)(cvm.ctx)
 	msg := C.GoString(cmsg)
+	if native.remote != nil {
+		native.remote.exit(sandboxPanic, 0, msg, uint64(cvm.gas), uint64(cvm.gas_used))
+	}
 
 	native.updateGas(uint64(cvm.gas), uint64(cvm.gas_used))
+	native.panicMsg(msg)
+}
+
+func (native *Native) panicMsg(msg string) {
 	native.Printf("[GoPanic] app:%s, msg:%s", native.name(), msg)
 
 	switch msg {
@@ -294,6 +436,9 @@ func GoPanic(cvm *C.vm_t, cmsg *C.char) {
 func GoRevert(cvm *C.vm_t, cmsg *C.char) {
 	native := (*Native)(cvm.ctx)
 	msg := C.GoString(cmsg)
+	if native.remote != nil {
+		native.remote.exit(sandboxRevert, 0, msg, uint64(cvm.gas), uint64(cvm.gas_used))
+	}
 
 	native.updateGas(uint64(cvm.gas), uint64(cvm.gas_used))
 	native.Printf("[GoRevert] app:%s, msg:%s", native.name(), msg)
@@ -305,6 +450,9 @@ func GoRevert(cvm *C.vm_t, cmsg *C.char) {
 func GoExit(cvm *C.vm_t, cstatus C.int32_t) {
 	native := (*Native)(cvm.ctx)
 	status := int32(cstatus)
+	if native.remote != nil {
+		native.remote.exit(sandboxExit, uint64(status), "", uint64(cvm.gas), uint64(cvm.gas_used))
+	}
 
 	native.updateGas(uint64(cvm.gas), uint64(cvm.gas_used))
 	native.Printf("[GoExit] app:%s, status:%d", native.name(), status)
@@ -316,6 +464,11 @@ func GoExit(cvm *C.vm_t, cstatus C.int32_t) {
 //export GoGrowMemory
 func GoGrowMemory(cvm *C.vm_t, pages C.int32_t) {
 	native := (*Native)(cvm.ctx)
+	if native.remote != nil {
+		mem := native.remote.growMemory(int32(pages), uint64(cvm.gas), uint64(cvm.gas_used))
+		C.update_mem(cvm, C.int32_t(pages), unsafe.Pointer(&mem[0]))
+		return
+	}
 	mem := native.memory()
 
 	if err := mem.GrowMem(int(pages) * wasmPageSize); err != nil {
@@ -327,25 +480,50 @@ func GoGrowMemory(cvm *C.vm_t, pages C.int32_t) {
 	native.Printf("[GoGrowMemory] ok: app:%s, pages:%d", native.name(), int(pages))
 }
 
-// GoFunc --
-//export GoFunc
-func GoFunc(cvm *C.vm_t, cname *C.char, cArgn C.int32_t, cArgs *C.uint64_t) uint64 {
+// GoFuncIndex calls the host function at index of the library table.
+//export GoFuncIndex
+func GoFuncIndex(cvm *C.vm_t, cindex C.uint32_t, cArgn C.int32_t, cArgs *C.uint64_t) uint64 {
 	native := (*Native)(cvm.ctx)
-	eng := native.engine()
 
 	args := make([]uint64, int(cArgn))
 	if len(args) > 0 {
 		C.copy_args((*C.uint64_t)(unsafe.Pointer(&args[0])), cArgs, cArgn)
 	}
-	index := int64(-1)
-	name := C.GoString(cname)
+	index := int(cindex)
+	if native.remote != nil {
+		ret, gas, gasUsed, pages, mem := native.remote.callHost(native.remote.hostName(index), args, uint64(cvm.gas), uint64(cvm.gas_used))
+		updateGas(cvm, gas, gasUsed)
+		C.update_mem(cvm, C.int32_t(pages), unsafe.Pointer(&mem[0]))
+		return ret
+	}
+	eng := native.engine()
 
 	native.updateGas(uint64(cvm.gas), uint64(cvm.gas_used))
+	if index >= len(native.dl.hosts) {
+		native.Printf("[GoFunc] Invalid Index: app:%s, index:%d", native.name(), index)
+		panic(fmt.Sprintf("[GoFunc] Invalid Index: app:%s, index:%d", native.name(), index))
+	}
+	name, envFunc := native.envTable().funcByIndex(native.dl.hosts[index])
+	ret := native.callEnvFunc(name, envFunc, args)
+
+	updateGas(cvm, eng.gas, eng.gasUsed)
+	updateMem(cvm, native)
+	return ret
+}
+
+// callHost calls the host function name, it panics like the interpreter.
+func (native *Native) callHost(name string, args []uint64) uint64 {
 	envFunc := native.getFuncByName(name)
 	if envFunc == nil {
 		native.Printf("[GoFunc] Not Exist: app:%s, name:%s", native.name(), name)
 		panic(fmt.Sprintf("[GoFunc] Not Exist: app:%s, name:%s", native.name(), name))
 	}
+	return native.callEnvFunc(name, envFunc, args)
+}
+
+func (native *Native) callEnvFunc(name string, envFunc EnvFunc, args []uint64) uint64 {
+	eng := native.engine()
+	index := int64(-1)
 
 	preFee := eng.GetFee()
 	cost, err := envFunc.Gas(index, eng, args)
@@ -369,10 +547,6 @@ func GoFunc(cvm *C.vm_t, cname *C.char, cArgn C.int32_t, cArgs *C.uint64_t) uint
 		native.Printf("[GoFunc] Call() fail: app:%s, name:%s, err:%s", native.name(), name, err)
 		panic(err)
 	}
-
-	updateGas(cvm, eng.gas, eng.gasUsed)
-	updateMem(cvm, native)
-	// native.app.logger.Debug("[GoFunc] Call() ok", "app", native.name(), "name", name, "cost", cost)
 	return ret
 }
 
@@ -384,6 +558,8 @@ type dynamicLib struct {
 	ref      uint64
 	file     string
 	so       unsafe.Pointer
+	f        *os.File // checked library passed to sandbox helpers
+	hosts    []int    // EnvTable indices of the library host function table
 	logger   log.Logger
 }
 
@@ -439,6 +615,10 @@ func (dl *dynamicLib) free() {
 			dl.so = nil
 			dl.logger.Printf("[dynamicLib] dlclose %s", dl.file)
 		}
+		if dl.f != nil {
+			dl.f.Close()
+			dl.f = nil
+		}
 		if dl.isDelete {
 			os.Remove(dl.file)
 			// dl.isDelete = false
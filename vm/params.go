@@ -105,11 +105,20 @@ const (
 	AddrSetGas   uint64 = 60
 	JsonGas      uint64 = 500
 
+	MaxBigIntBits    = 4096 // Maximum bits of the numbers of the extended TC_BigInt api in unbounded mode
+	BigIntWordGas    = 3    // Times the 256-bit words of the operands of the extended TC_BigInt api, squared for products and quotients
+	MaxDecimalDigits = 256  // Maximum digits of the operands and results of the TC_Decimal api
+	MaxDecimalScale  = 36   // Maximum digits after the point of the results of the TC_Decimal api
+
+	MaxJsonMemory = 4 * 1024 * 1024 // Maximum bytes held by the JSON handles of a transaction
+
 	// Precompiled contract gas prices
 
 	EcrecoverGas            uint64 = 3000   // Elliptic curve sender recovery gas price
 	Sha256BaseGas           uint64 = 60     // Base price for a SHA256 operation
 	Sha256PerWordGas        uint64 = 12     // Per-word price for a SHA256 operation
+	Sha512PerWordGas        uint64 = 12     // Per-word price for a SHA512 operation
+	Blake2PerWordGas        uint64 = 6      // Per-word price for a BLAKE2b or BLAKE2s operation
 	Ripemd160BaseGas        uint64 = 600    // Base price for a RIPEMD160 operation
 	Ripemd160PerWordGas     uint64 = 120    // Per-word price for a RIPEMD160 operation
 	IdentityBaseGas         uint64 = 15     // Base price for a data copy operation
@@ -119,4 +128,8 @@ const (
 	Bn256ScalarMulGas       uint64 = 40000  // Gas needed for an elliptic curve scalar multiplication
 	Bn256PairingBaseGas     uint64 = 100000 // Base price for an elliptic curve pairing check
 	Bn256PairingPerPointGas uint64 = 80000  // Per-point price for an elliptic curve pairing check
+	Ed25519VerifyGas        uint64 = 2000   // Base price for an ed25519 signature verification
+	Ed25519PerWordGas       uint64 = 12     // Per-word price of the message of an ed25519 signature verification
+	P256VerifyGas           uint64 = 3450   // Base price for a secp256r1 signature verification
+	P256PerWordGas          uint64 = 12     // Per-word price of the message of a secp256r1 signature verification
 )
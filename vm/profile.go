@@ -0,0 +1,102 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// GasProfile accumulates the gas charged per contract location over any
+// number of Engine.Run calls. Locations are source lines when the contract
+// carries DWARF, function+offset otherwise, and "native" for AOT code.
+type GasProfile struct {
+	lock    sync.Mutex
+	entries map[string]*GasProfileEntry
+}
+
+type GasProfileEntry struct {
+	App      string
+	Func     string
+	Location string
+	Gas      uint64
+	Hits     uint64
+}
+
+func NewGasProfile() *GasProfile {
+	return &GasProfile{
+		entries: make(map[string]*GasProfileEntry),
+	}
+}
+
+// SetGasProfile starts charging gas into p; nil stops profiling.
+func (eng *Engine) SetGasProfile(p *GasProfile) {
+	eng.profile = p
+}
+
+func (p *GasProfile) add(app *APP, cost uint64) {
+	if app == nil {
+		return
+	}
+
+	fn, where := "native", "native"
+	if !app.runsNative() {
+		fnIndex, pc, ok := app.interpFrame()
+		if !ok {
+			return
+		}
+		fn = app.funcName(fnIndex)
+		var line *SourceLine
+		where, line = app.locate(fnIndex, instrPC(pc))
+		if line != nil {
+			where = line.String()
+		}
+	}
+
+	key := app.Name + "|" + fn + "|" + where
+	p.lock.Lock()
+	e, ok := p.entries[key]
+	if !ok {
+		e = &GasProfileEntry{App: app.Name, Func: fn, Location: where}
+		p.entries[key] = e
+	}
+	e.Gas += cost
+	e.Hits++
+	p.lock.Unlock()
+}
+
+// Entries returns the profile sorted by gas, most expensive first.
+func (p *GasProfile) Entries() []GasProfileEntry {
+	p.lock.Lock()
+	entries := make([]GasProfileEntry, 0, len(p.entries))
+	for _, e := range p.entries {
+		entries = append(entries, *e)
+	}
+	p.lock.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Gas != entries[j].Gas {
+			return entries[i].Gas > entries[j].Gas
+		}
+		return entries[i].Location < entries[j].Location
+	})
+	return entries
+}
+
+// WriteTo writes the profile as a text table.
+func (p *GasProfile) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	n, err := fmt.Fprintf(w, "%12s %10s  %-24s %s\n", "gas", "hits", "func", "location")
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+	for _, e := range p.Entries() {
+		n, err = fmt.Fprintf(w, "%12d %10d  %-24s %s\n", e.Gas, e.Hits, e.Func, e.Location)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,444 @@
+package vm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Host functions whose results depend on the chain (storage, balances, block
+// context, sub-calls) are registered with RegisterStateFunc and a signature
+// "ARGS:RET" describing how to decode them:
+//
+//	i  integer
+//	s  pointer to a C string
+//	b  pointer to bytes, the length is the next (i) argument
+//	v  no result (RET only)
+//
+// e.g. "bi:s" for char* TC_StoragePureGetString(const uint8_t* key, uint32_t size).
+// An Engine with a Recorder logs every such call, an Engine with a Replayer
+// serves them from a recording instead of calling the host.
+//
+// Host functions that only compute on their arguments, the contract memory
+// and the Engine are registered with RegisterPureFunc, and run again on
+// replay. A replay can not tell what other host functions change, so a
+// verified Run skips the contracts importing any of them.
+
+const (
+	recordRun    = "@run"
+	recordReturn = "@return"
+)
+
+// HostBytes is a byte string written to a recording as a JSON string, or as
+// {"hex": "..."} if it is not valid UTF-8.
+type HostBytes []byte
+
+func (b HostBytes) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"hex": hex.EncodeToString(b)})
+}
+
+func (b *HostBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = HostBytes(s)
+		return nil
+	}
+	var h struct {
+		Hex string `json:"hex"`
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(h.Hex)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// HostRecord is one line of a recording: a host call with its decoded
+// arguments and result, or the start (@run) and the end (@return) of a
+// top level Engine.Run.
+type HostRecord struct {
+	Seq   int         `json:"seq"`
+	Depth int         `json:"depth"`
+	App   string      `json:"app"`
+	Func  string      `json:"func"`
+	Args  []HostBytes `json:"args,omitempty"`
+	Ret   uint64      `json:"ret"`
+	Out   HostBytes   `json:"out,omitempty"`
+	Gas   uint64      `json:"gas,omitempty"`
+	Err   string      `json:"err,omitempty"`
+}
+
+func (rec *HostRecord) String() string {
+	var args []string
+	for _, a := range rec.Args {
+		args = append(args, strconv.Quote(string(a)))
+	}
+	s := fmt.Sprintf("#%d %s: %s(%s) ret=%d", rec.Seq, rec.App, rec.Func, strings.Join(args, ", "), rec.Ret)
+	if rec.Out != nil {
+		s += fmt.Sprintf(" out=%q", string(rec.Out))
+	}
+	if rec.Gas != 0 {
+		s += fmt.Sprintf(" gas=%d", rec.Gas)
+	}
+	if rec.Err != "" {
+		s += " err=" + rec.Err
+	}
+	return s
+}
+
+// sameCall reports whether rec and got are the same call with the same
+// inputs.
+func (rec *HostRecord) sameCall(got *HostRecord) bool {
+	if rec.Depth != got.Depth || rec.App != got.App || rec.Func != got.Func || len(rec.Args) != len(got.Args) {
+		return false
+	}
+	for i := range rec.Args {
+		if !bytes.Equal(rec.Args[i], got.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type hostSig struct {
+	args string
+	ret  byte
+}
+
+func parseHostSig(sig string) hostSig {
+	i := strings.IndexByte(sig, ':')
+	if i < 0 || i != len(sig)-2 || strings.Trim(sig[:i], "isb") != "" || strings.IndexByte("isv", sig[i+1]) < 0 {
+		panic(fmt.Sprintf("invalid host signature: %s", sig))
+	}
+	return hostSig{args: sig[:i], ret: sig[i+1]}
+}
+
+// stateFunc is a host function registered with RegisterStateFunc.
+type stateFunc struct {
+	name string
+	sig  hostSig
+	fn   EnvFunc
+}
+
+// RegisterStateFunc registers a host function that reads or changes state
+// outside of the contract, so that its calls can be recorded and replayed.
+func (env *EnvTable) RegisterStateFunc(name string, fn EnvFunc, sig string) {
+	env.RegisterFunc(name, &stateFunc{name: name, sig: parseHostSig(sig), fn: fn})
+}
+
+// pureFunc is a host function registered with RegisterPureFunc.
+type pureFunc struct {
+	EnvFunc
+}
+
+// RegisterPureFunc registers a host function computing its result from its
+// arguments and the memory of the contract only, which can run again when its
+// calls are replayed.
+func (env *EnvTable) RegisterPureFunc(name string, fn EnvFunc) {
+	env.RegisterFunc(name, &pureFunc{fn})
+}
+
+// frameFunc is a host function registered with RegisterFrameFunc.
+type frameFunc struct {
+	EnvFunc
+}
+
+// RegisterFrameFunc registers a host function that also reads or changes the
+// running frame or the Engine, like the allocator, the json handles, the
+// message, the gas, the logger or the control flow. It has no effects outside of the
+// call, so it can run again on the Engine replaying its calls.
+func (env *EnvTable) RegisterFrameFunc(name string, fn EnvFunc) {
+	env.RegisterFunc(name, &frameFunc{fn})
+}
+
+// replayable reports whether all the host functions imported by app were
+// registered with RegisterStateFunc, RegisterPureFunc or RegisterFrameFunc.
+func (app *APP) replayable() bool {
+	for i := range app.Module.FunctionIndexSpace {
+		switch app.Module.FunctionIndexSpace[i].Host.(type) {
+		case nil, *stateFunc, *pureFunc, *frameFunc:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (f *stateFunc) Gas(index int64, ops interface{}, args []uint64) (uint64, error) {
+	return f.fn.Gas(index, ops, args)
+}
+
+func (f *stateFunc) Call(index int64, ops interface{}, args []uint64) (uint64, error) {
+	eng := ops.(*Engine)
+	if eng.replayer != nil {
+		return eng.replayer.call(eng, f, f.record(eng, args))
+	}
+	if eng.recorder == nil {
+		return f.fn.Call(index, ops, args)
+	}
+
+	rec := f.record(eng, args)
+	gasUsed := eng.gasUsed
+	ret, err := f.fn.Call(index, ops, args)
+	rec.Ret = ret
+	rec.Gas = eng.gasUsed - gasUsed
+	if err != nil {
+		rec.Err = err.Error()
+	} else if f.sig.ret == 's' && ret != 0 {
+		rec.Out, _ = eng.runningFrame.VM.VMemory().GetString(ret)
+	}
+	eng.recorder.write(rec)
+	return ret, err
+}
+
+// record decodes the arguments of a call; unreadable arguments are nil.
+func (f *stateFunc) record(eng *Engine, args []uint64) *HostRecord {
+	app := eng.runningFrame
+	rec := &HostRecord{Depth: eng.FrameIndex + 1, App: app.Name, Func: f.name}
+	vmem := app.VM.VMemory()
+	for i := 0; i < len(f.sig.args) && i < len(args); i++ {
+		var v []byte
+		switch f.sig.args[i] {
+		case 'i':
+			v = []byte(strconv.FormatUint(args[i], 10))
+		case 's':
+			v, _ = vmem.GetString(args[i])
+		case 'b':
+			if i+1 < len(args) {
+				v, _ = vmem.GetBytes(args[i], int(args[i+1]))
+			}
+		}
+		rec.Args = append(rec.Args, v)
+	}
+	return rec
+}
+
+// Recorder writes the host interactions of the Engines it is set on to a
+// recording, one JSON HostRecord per line.
+type Recorder struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+	seq  int
+	err  error
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// SetRecorder starts recording host interactions into r; nil stops it.
+func (eng *Engine) SetRecorder(r *Recorder) {
+	eng.recorder = r
+}
+
+func (r *Recorder) write(rec *HostRecord) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.seq++
+	rec.Seq = r.seq
+	if r.err == nil {
+		r.err = r.enc.Encode(rec)
+	}
+}
+
+// Err returns the first error writing the recording.
+func (r *Recorder) Err() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.err
+}
+
+// ReplayDivergence describes the first point where a replay did not follow
+// its recording. Want is nil if the recording was exhausted.
+type ReplayDivergence struct {
+	Want *HostRecord
+	Got  *HostRecord
+}
+
+func (d *ReplayDivergence) String() string {
+	if d.Want == nil {
+		return fmt.Sprintf("unexpected %s, recording exhausted", d.Got)
+	}
+	return fmt.Sprintf("expected %s, got %s", d.Want, d.Got)
+}
+
+// Replayer serves host calls from a recording instead of the chain. It also
+// implements StateDB with no contract code or info, so an Engine replaying a
+// recording needs no StateDB at all; sub-calls are not executed but return
+// their recorded result and gas.
+type Replayer struct {
+	lock        sync.Mutex
+	records     []*HostRecord
+	next        int
+	diverged    *ReplayDivergence
+	checkReturn bool
+}
+
+// NewReplayer reads a recording written by a Recorder.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	p := &Replayer{checkReturn: true}
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		rec := new(HostRecord)
+		err := dec.Decode(rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("recording #%d: %v", len(p.records)+1, err)
+		}
+		p.records = append(p.records, rec)
+	}
+	return p, nil
+}
+
+// SetReplayer makes eng serve host calls from r; nil stops replaying.
+func (eng *Engine) SetReplayer(r *Replayer) {
+	eng.replayer = r
+}
+
+func (p *Replayer) GetContractCode([]byte) []byte     { return nil }
+func (p *Replayer) GetContractInfo([]byte) []byte     { return nil }
+func (p *Replayer) SetContractInfo(key, value []byte) {}
+
+// Divergence returns the first divergence from the recording, or nil.
+func (p *Replayer) Divergence() *ReplayDivergence {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.diverged
+}
+
+// Remaining returns the number of recorded entries not replayed yet.
+func (p *Replayer) Remaining() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.records) - p.next
+}
+
+// match consumes the next record at got's depth, skipping the host calls
+// made inside sub-calls that are not executed on replay.
+func (p *Replayer) match(got *HostRecord) (*HostRecord, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.diverged != nil {
+		return nil, ErrReplayDiverged
+	}
+	for p.next < len(p.records) && p.records[p.next].Depth > got.Depth {
+		p.next++
+	}
+	if p.next == len(p.records) {
+		p.diverged = &ReplayDivergence{Got: got}
+		return nil, ErrReplayDiverged
+	}
+	want := p.records[p.next]
+	p.next++
+	got.Seq = want.Seq
+	if !want.sameCall(got) {
+		p.diverged = &ReplayDivergence{Want: want, Got: got}
+		return nil, ErrReplayDiverged
+	}
+	return want, nil
+}
+
+func (p *Replayer) call(eng *Engine, f *stateFunc, got *HostRecord) (uint64, error) {
+	want, err := p.match(got)
+	if err != nil {
+		return 0, err
+	}
+	if !eng.UseGas(want.Gas) {
+		return 0, ErrOutOfGas
+	}
+	if want.Err != "" {
+		return 0, replayError(want.Err)
+	}
+	if f.sig.ret == 's' && want.Ret != 0 {
+		return eng.runningFrame.VM.VMemory().SetBytes(want.Out)
+	}
+	return want.Ret, nil
+}
+
+// replayedErrors are returned as is so that callers comparing errors see
+// the recorded one.
+var replayedErrors = []error{
+	ErrExecutionReverted, ErrOutOfGas, ErrContractRequire, ErrContractAssert,
+	ErrExecutionExit, ErrContractAbort, ErrInvalidApiArgs, ErrBalanceNotEnough,
+	ErrMemoryGet, ErrMemorySet, ErrContractNoCode, ErrOverFrame,
+}
+
+func replayError(msg string) error {
+	for _, err := range replayedErrors {
+		if err.Error() == msg {
+			return err
+		}
+	}
+	return errors.New(msg)
+}
+
+// recordRun records or replays the start of a top level Engine.Run.
+func (eng *Engine) recordRun(app *APP, action, args string) error {
+	if eng.recorder == nil && eng.replayer == nil {
+		return nil
+	}
+	rec := &HostRecord{App: app.Name, Func: recordRun, Args: []HostBytes{HostBytes(action), HostBytes(args)}}
+	if eng.replayer != nil {
+		_, err := eng.replayer.match(rec)
+		return err
+	}
+	eng.recorder.write(rec)
+	return nil
+}
+
+// recordReturn records the result of a top level Engine.Run, or checks it
+// against the recording.
+func (eng *Engine) recordReturn(app *APP, ret, gas uint64, err error) error {
+	if eng.recorder == nil && eng.replayer == nil {
+		return err
+	}
+	rec := &HostRecord{App: app.Name, Func: recordReturn, Ret: ret, Gas: gas}
+	if err != nil {
+		rec.Err = err.Error()
+	} else if ret != 0 {
+		rec.Out, _ = app.VM.VMemory().GetString(ret)
+	}
+	if eng.recorder != nil {
+		eng.recorder.write(rec)
+		return err
+	}
+
+	want, merr := eng.replayer.match(rec)
+	if merr != nil {
+		return merr
+	}
+	if !eng.replayer.checkReturn {
+		return err
+	}
+	if want.Gas != rec.Gas || want.Err != rec.Err || !bytes.Equal(want.Out, rec.Out) {
+		eng.replayer.lock.Lock()
+		eng.replayer.diverged = &ReplayDivergence{Want: want, Got: rec}
+		eng.replayer.lock.Unlock()
+		return ErrReplayDiverged
+	}
+	return err
+}
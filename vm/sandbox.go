@@ -0,0 +1,575 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// A sandboxed Native runs thunderchain_main of its library in a helper
+// process, one per run, instead of in the node. The wasm memory is a shared
+// file mapped by both processes; the helper sends host calls, memory growth
+// and the end of the run over a pipe, and the node copies the memory between
+// the shared file and the VM around each of them.
+//
+// If the helper dies, whether it crashed or hit a limit of its host, the
+// artifact is blacklisted and the run is taken over by the interpreter: the
+// memory, gas, JSON handles and state are set back to their values before the
+// run, so the result is the one of a node running the contract on the
+// interpreter only. A sandboxed Native is therefore used only if Engine.State
+// has snapshots and no Recorder would see the host calls of the dead run. In
+// a replay, which does not change the chain, the run traps with
+// ErrNativeCrashed instead.
+//
+// The helper is a program calling RunSandboxHelper first in main, by default
+// the running program. It loads the library checked by the node through an
+// inherited descriptor, then limits its CPU time, files and, on linux, its
+// system calls before running the contract.
+
+// TCVM_SANDBOX_HELPER marks a process started as a sandbox helper.
+const TCVM_SANDBOX_HELPER = "TCVM_SANDBOX_HELPER"
+
+// SandboxConfig --
+type SandboxConfig struct {
+	Helper     string   // program calling RunSandboxHelper, the running program if empty
+	Args       []string // arguments of the helper
+	CPUSeconds uint64   // CPU time limit of a run, 10 if 0
+	NoSeccomp  bool     // do not restrict the system calls of the helper
+}
+
+// sandbox messages, see sandboxMsg
+const (
+	sandboxRun    = iota + 1 // node: gas, gas_used; pages, action, args, memory size, cpu seconds, seccomp; imported functions
+	sandboxReply             // node: gas, gas_used; result, memory size, pages
+	sandboxCall              // helper: gas, gas_used; args; function name
+	sandboxGrow              // helper: gas, gas_used; pages
+	sandboxDone              // helper: gas, gas_used; result
+	sandboxPanic             // helper: gas, gas_used; message
+	sandboxRevert            // helper: gas, gas_used; message
+	sandboxExit              // helper: gas, gas_used; status
+	sandboxFail              // helper: message
+)
+
+// the descriptors inherited by the helper
+const (
+	sandboxLibFd = 3 + iota
+	sandboxShmFd
+	sandboxInFd
+	sandboxOutFd
+)
+
+const sandboxMaxVals = 1 << 16
+const sandboxMaxStr = 1 << 20
+
+type sandboxMsg struct {
+	Op      uint8
+	Gas     uint64
+	GasUsed uint64
+	Vals    []uint64
+	Str     string
+}
+
+func writeSandboxMsg(w io.Writer, m *sandboxMsg) error {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(m.Op)
+	binary.Write(buf, binary.LittleEndian, m.Gas)
+	binary.Write(buf, binary.LittleEndian, m.GasUsed)
+	binary.Write(buf, binary.LittleEndian, uint32(len(m.Vals)))
+	binary.Write(buf, binary.LittleEndian, m.Vals)
+	binary.Write(buf, binary.LittleEndian, uint32(len(m.Str)))
+	buf.WriteString(m.Str)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func readSandboxMsg(r io.Reader) (*sandboxMsg, error) {
+	var head [21]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	m := &sandboxMsg{
+		Op:      head[0],
+		Gas:     binary.LittleEndian.Uint64(head[1:]),
+		GasUsed: binary.LittleEndian.Uint64(head[9:]),
+	}
+	n := binary.LittleEndian.Uint32(head[17:])
+	if n > sandboxMaxVals {
+		return nil, fmt.Errorf("sandbox: %d values", n)
+	}
+	m.Vals = make([]uint64, n)
+	if err := binary.Read(r, binary.LittleEndian, m.Vals); err != nil {
+		return nil, err
+	}
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > sandboxMaxStr {
+		return nil, fmt.Errorf("sandbox: %d bytes string", size)
+	}
+	str := make([]byte, size)
+	if _, err := io.ReadFull(r, str); err != nil {
+		return nil, err
+	}
+	m.Str = string(str)
+	return m, nil
+}
+
+// sharedMemory is a file mapped by the node and the helper.
+type sharedMemory struct {
+	f    *os.File
+	data []byte
+}
+
+func newSharedMemory(size int) (*sharedMemory, error) {
+	dir := "/dev/shm"
+	if _, err := os.Stat(dir); err != nil {
+		dir = ""
+	}
+	f, err := ioutil.TempFile(dir, "tcvm-shm")
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(f.Name())
+
+	m := &sharedMemory{f: f}
+	if err := f.Truncate(int64(size)); err != nil {
+		m.close()
+		return nil, err
+	}
+	if err := m.remap(size); err != nil {
+		m.close()
+		return nil, err
+	}
+	return m, nil
+}
+
+// remap maps size bytes of the file, resized by the node.
+func (m *sharedMemory) remap(size int) error {
+	if len(m.data) == size {
+		return nil
+	}
+	if m.data != nil {
+		syscall.Munmap(m.data)
+		m.data = nil
+	}
+	if size == 0 {
+		return nil
+	}
+	data, err := syscall.Mmap(int(m.f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	m.data = data
+	return nil
+}
+
+// store copies the VM memory to the file, growing it if needed.
+func (m *sharedMemory) store(mem []byte) error {
+	if len(mem) != len(m.data) {
+		if err := m.f.Truncate(int64(len(mem))); err != nil {
+			return err
+		}
+		if err := m.remap(len(mem)); err != nil {
+			return err
+		}
+	}
+	copy(m.data, mem)
+	return nil
+}
+
+func (m *sharedMemory) close() {
+	m.remap(0)
+	m.f.Close()
+}
+
+// sandboxProc is a helper process seen from the node.
+type sandboxProc struct {
+	cmd *exec.Cmd
+	in  *os.File
+	out *os.File
+}
+
+func startSandbox(cfg *SandboxConfig, lib, shm *os.File) (*sandboxProc, error) {
+	helper := cfg.Helper
+	if helper == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		helper = exe
+	}
+
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		inR.Close()
+		inW.Close()
+		return nil, err
+	}
+
+	cmd := exec.Command(helper, cfg.Args...)
+	cmd.Env = append(os.Environ(), TCVM_SANDBOX_HELPER+"=1")
+	cmd.ExtraFiles = []*os.File{lib, shm, inR, outW}
+	err = cmd.Start()
+	inR.Close()
+	outW.Close()
+	if err != nil {
+		inW.Close()
+		outR.Close()
+		return nil, err
+	}
+	return &sandboxProc{cmd: cmd, in: inW, out: outR}, nil
+}
+
+func (p *sandboxProc) send(m *sandboxMsg) error {
+	return writeSandboxMsg(p.in, m)
+}
+
+func (p *sandboxProc) recv() (*sandboxMsg, error) {
+	return readSandboxMsg(p.out)
+}
+
+// wait reaps the helper, killing it if still running, and returns how it
+// ended.
+func (p *sandboxProc) wait() string {
+	p.in.Close()
+	p.out.Close()
+	p.cmd.Process.Kill()
+	p.cmd.Wait()
+	return p.cmd.ProcessState.String()
+}
+
+// runSandboxed runs the library in a helper, the data is as for call_main.
+func (native *Native) runSandboxed(data []uint64) (uint32, uint64, uint64) {
+	eng := native.engine()
+	mem := native.memory()
+	gas, gasUsed := data[0], data[1]
+
+	shm, err := newSharedMemory(len(mem.Memory))
+	if err != nil {
+		native.crashed(gas, gasUsed, err.Error())
+	}
+	defer shm.close()
+	copy(shm.data, mem.Memory)
+
+	proc, err := startSandbox(native.sandbox, native.dl.f, shm.f)
+	if err != nil {
+		native.crashed(gas, gasUsed, err.Error())
+	}
+	defer proc.wait()
+
+	cpu := native.sandbox.CPUSeconds
+	if cpu == 0 {
+		cpu = 10
+	}
+	seccomp := uint64(1)
+	if native.sandbox.NoSeccomp {
+		seccomp = 0
+	}
+	run := &sandboxMsg{
+		Op:      sandboxRun,
+		Gas:     gas,
+		GasUsed: gasUsed,
+		Vals:    []uint64{data[2], data[3], data[4], uint64(len(shm.data)), cpu, seccomp},
+		Str:     strings.Join(hostImports(native.app.Module), ","),
+	}
+	if err := proc.send(run); err != nil {
+		native.crashed(gas, gasUsed, proc.wait())
+	}
+
+	for {
+		m, err := proc.recv()
+		if err != nil {
+			native.crashed(gas, gasUsed, proc.wait())
+		}
+		if m.Op == sandboxFail {
+			native.crashed(gas, gasUsed, m.Str)
+		}
+		native.updateGas(m.Gas, m.GasUsed)
+		copy(mem.Memory, shm.data)
+
+		switch m.Op {
+		case sandboxCall:
+			ret := native.callHost(m.Str, m.Vals)
+			if err := shm.store(mem.Memory); err != nil {
+				native.crashed(gas, gasUsed, err.Error())
+			}
+			reply := &sandboxMsg{
+				Op:      sandboxReply,
+				Gas:     eng.gas,
+				GasUsed: eng.gasUsed,
+				Vals:    []uint64{ret, uint64(len(shm.data)), uint64(mem.HeapSize() / wasmPageSize)},
+			}
+			if err := proc.send(reply); err != nil {
+				native.crashed(gas, gasUsed, proc.wait())
+			}
+
+		case sandboxGrow:
+			if len(m.Vals) != 1 {
+				native.crashed(gas, gasUsed, "bad grow message")
+			}
+			if err := mem.GrowMem(int(m.Vals[0]) * wasmPageSize); err != nil {
+				native.Printf("[GoGrowMem] fail: app:%s, pages:%d, err:%s", native.name(), m.Vals[0], err)
+				panic(err)
+			}
+			if err := shm.store(mem.Memory); err != nil {
+				native.crashed(gas, gasUsed, err.Error())
+			}
+			reply := &sandboxMsg{
+				Op:      sandboxReply,
+				Gas:     eng.gas,
+				GasUsed: eng.gasUsed,
+				Vals:    []uint64{0, uint64(len(shm.data)), uint64(mem.HeapSize() / wasmPageSize)},
+			}
+			if err := proc.send(reply); err != nil {
+				native.crashed(gas, gasUsed, proc.wait())
+			}
+
+		case sandboxDone:
+			if len(m.Vals) != 1 {
+				native.crashed(gas, gasUsed, "bad done message")
+			}
+			return uint32(m.Vals[0]), m.Gas, m.GasUsed
+
+		case sandboxPanic:
+			native.panicMsg(m.Str)
+
+		case sandboxRevert:
+			native.Printf("[GoRevert] app:%s, msg:%s", native.name(), m.Str)
+			panic(ErrExecutionReverted)
+
+		case sandboxExit:
+			if len(m.Vals) != 1 {
+				native.crashed(gas, gasUsed, "bad exit message")
+			}
+			native.Printf("[GoExit] app:%s, status:%d", native.name(), int32(m.Vals[0]))
+			native.ret = m.Vals[0]
+			panic(ErrExecutionExit)
+
+		default:
+			native.crashed(gas, gasUsed, fmt.Sprintf("unknown message %d", m.Op))
+		}
+	}
+}
+
+// crashed ends the run of a helper that died or could not run the library,
+// see errSandboxDied. In a replay the run traps instead, with all its gas used
+// so the result does not depend on when the helper died.
+func (native *Native) crashed(gas, gasUsed uint64, state string) {
+	eng := native.engine()
+	native.logger.Error("[Native] sandbox helper died", "app", native.name(), "state", state)
+	if eng.replayer != nil {
+		eng.aots.blacklist(native.app, aotErrCrash)
+		native.updateGas(0, gasUsed+gas)
+		panic(ErrNativeCrashed)
+	}
+	panic(errSandboxDied)
+}
+
+// errSandboxDied ends a sandboxed RunCMain whose helper died, after the
+// changes of the run are undone; APP.Run then blacklists the artifact and
+// runs the interpreter.
+var errSandboxDied = errors.New("vm: sandbox helper died")
+
+// canFallBack reports whether a dead helper can be replaced by the
+// interpreter, or the run is a replay.
+func (native *Native) canFallBack() bool {
+	eng := native.engine()
+	if eng == nil {
+		return false
+	}
+	if eng.replayer != nil {
+		return true
+	}
+	_, ok := eng.State.(stateSnapshotter)
+	return ok && eng.recorder == nil
+}
+
+// saveRun saves what a sandboxed run changes and returns the function setting
+// it back.
+func (native *Native) saveRun() func() {
+	eng := native.engine()
+	app := native.app
+	gas, gasUsed := eng.gas, eng.gasUsed
+	trap, dump := eng.trap, eng.dump
+	json, jsonMemory := app.json, eng.jsonMemory
+	mem := native.memory().Snapshot()
+
+	ss, _ := eng.State.(stateSnapshotter)
+	revid := -1
+	if ss != nil {
+		revid = ss.Snapshot()
+	}
+	return func() {
+		if ss != nil {
+			ss.RevertToSnapshot(revid)
+		}
+		native.memory().Restore(mem)
+		app.json, eng.jsonMemory = json, jsonMemory
+		eng.trap, eng.dump = trap, dump
+		eng.gas, eng.gasUsed = gas, gasUsed
+	}
+}
+
+func newSandboxNative(app *APP, file string, f *os.File, cfg *SandboxConfig) *Native {
+	dl := newDynamicLib(file, nil, app.logger)
+	dl.f = f
+	return &Native{
+		app:      app,
+		logger:   app.logger,
+		dl:       dl,
+		t:        time.Now(),
+		codeHash: app.codeHash,
+		sandbox:  cfg,
+	}
+}
+
+// sandboxChild is the node seen from a helper.
+type sandboxChild struct {
+	in    *os.File
+	out   *os.File
+	shm   *sharedMemory
+	hosts []string // host function table of the library
+}
+
+// RunSandboxHelper runs a native contract and exits if the process was
+// started as a sandbox helper, otherwise it returns.
+func RunSandboxHelper() {
+	if os.Getenv(TCVM_SANDBOX_HELPER) != "1" {
+		return
+	}
+	os.Exit(serveSandbox())
+}
+
+func serveSandbox() int {
+	c := &sandboxChild{
+		in:  os.NewFile(sandboxInFd, "in"),
+		out: os.NewFile(sandboxOutFd, "out"),
+		shm: &sharedMemory{f: os.NewFile(sandboxShmFd, "shm")},
+	}
+	run, err := readSandboxMsg(c.in)
+	if err != nil {
+		return 1
+	}
+	if run.Op != sandboxRun || len(run.Vals) != 6 {
+		c.fail("bad run message")
+		return 1
+	}
+	if err := c.shm.remap(int(run.Vals[3])); err != nil {
+		c.fail(err.Error())
+		return 1
+	}
+
+	so, err := openNativeLib(fmt.Sprintf("/proc/self/fd/%d", sandboxLibFd))
+	if err != nil {
+		c.fail(err.Error())
+		return 1
+	}
+	var imports []string
+	if run.Str != "" {
+		imports = strings.Split(run.Str, ",")
+	}
+	if _, err := resolveHostFuncs(so, NewEnvTable(), imports); err != nil {
+		c.fail(err.Error())
+		return 1
+	}
+	c.hosts, _ = envFuncNames(so)
+	if err := restrictSandbox(run.Vals[4], run.Vals[5] != 0); err != nil {
+		c.fail(err.Error())
+		return 1
+	}
+
+	native := &Native{remote: c}
+	data := []uint64{run.Gas, run.GasUsed, run.Vals[0], run.Vals[1], run.Vals[2]}
+	ret, gas, gasUsed := native.runRemote(so, data, c.shm.data)
+	c.send(&sandboxMsg{Op: sandboxDone, Gas: gas, GasUsed: gasUsed, Vals: []uint64{uint64(ret)}})
+	return 0
+}
+
+// restrictSandbox limits the resources and system calls of the helper. A
+// helper going over them dies, and the node falls back to the interpreter.
+func restrictSandbox(cpu uint64, seccomp bool) error {
+	limits := []struct {
+		resource int
+		max      uint64
+	}{
+		{syscall.RLIMIT_CPU, cpu},
+		{syscall.RLIMIT_FSIZE, 0},
+		{syscall.RLIMIT_CORE, 0},
+	}
+	for _, l := range limits {
+		if err := syscall.Setrlimit(l.resource, &syscall.Rlimit{Cur: l.max, Max: l.max}); err != nil {
+			return fmt.Errorf("setrlimit %d: %v", l.resource, err)
+		}
+	}
+	if seccomp {
+		return installSeccomp()
+	}
+	return nil
+}
+
+func (c *sandboxChild) send(m *sandboxMsg) {
+	if err := writeSandboxMsg(c.out, m); err != nil {
+		os.Exit(1)
+	}
+}
+
+func (c *sandboxChild) recv() *sandboxMsg {
+	m, err := readSandboxMsg(c.in)
+	if err != nil || m.Op != sandboxReply || len(m.Vals) != 3 {
+		os.Exit(1)
+	}
+	if err := c.shm.remap(int(m.Vals[1])); err != nil {
+		os.Exit(1)
+	}
+	return m
+}
+
+func (c *sandboxChild) fail(msg string) {
+	c.send(&sandboxMsg{Op: sandboxFail, Str: msg})
+}
+
+// callHost calls a host function in the node, returning its result, the gas,
+// and the memory with its pages.
+func (c *sandboxChild) callHost(name string, args []uint64, gas, gasUsed uint64) (uint64, uint64, uint64, int32, []byte) {
+	c.send(&sandboxMsg{Op: sandboxCall, Gas: gas, GasUsed: gasUsed, Vals: args, Str: name})
+	m := c.recv()
+	return m.Vals[0], m.Gas, m.GasUsed, int32(m.Vals[2]), c.shm.data
+}
+
+// hostName returns the name of the host function at index of the library
+// table, the node rejects the empty name.
+func (c *sandboxChild) hostName(index int) string {
+	if index >= len(c.hosts) {
+		return ""
+	}
+	return c.hosts[index]
+}
+
+func (c *sandboxChild) growMemory(pages int32, gas, gasUsed uint64) []byte {
+	c.send(&sandboxMsg{Op: sandboxGrow, Gas: gas, GasUsed: gasUsed, Vals: []uint64{uint64(pages)}})
+	c.recv()
+	return c.shm.data
+}
+
+// exit ends the run with a panic, revert or exit of the contract.
+func (c *sandboxChild) exit(op uint8, status uint64, msg string, gas, gasUsed uint64) {
+	m := &sandboxMsg{Op: op, Gas: gas, GasUsed: gasUsed, Str: msg}
+	if op == sandboxExit {
+		m.Vals = []uint64{status}
+	}
+	c.send(m)
+	os.Exit(0)
+}
@@ -0,0 +1,92 @@
+//go:build (linux && amd64) || (linux && arm64)
+// +build linux,amd64 linux,arm64
+
+package vm
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// The seccomp filter of a sandbox helper allows the few system calls the
+// helper and its Go runtime need once the library is loaded: reading and
+// writing its inherited descriptors, mapping memory, threads, signals, timers
+// and exiting. Any other call fails with EPERM, so the library can not open
+// files or sockets, run programs or trace other processes. clone3 fails with
+// ENOSYS, making the C library fall back to clone, which is only allowed for
+// threads.
+
+const (
+	bpfLdWAbs = 0x20 // BPF_LD | BPF_W | BPF_ABS
+	bpfJeqK   = 0x15 // BPF_JMP | BPF_JEQ | BPF_K
+	bpfJsetK  = 0x45 // BPF_JMP | BPF_JSET | BPF_K
+	bpfRetK   = 0x06 // BPF_RET | BPF_K
+
+	seccompRetAllow = 0x7fff0000
+	seccompRetErrno = 0x00050000
+
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTsync = 1
+	prSetNoNewPrivs        = 38
+
+	// offsets in struct seccomp_data
+	seccompDataNr   = 0
+	seccompDataArch = 4
+	seccompDataArg0 = 16
+
+	cloneThread = 0x10000
+)
+
+type sockFilter struct {
+	code uint16
+	jt   uint8
+	jf   uint8
+	k    uint32
+}
+
+type sockFprog struct {
+	len    uint16
+	filter *sockFilter
+}
+
+func seccompFilter() []sockFilter {
+	deny := sockFilter{bpfRetK, 0, 0, seccompRetErrno | uint32(syscall.EPERM)}
+	allow := sockFilter{bpfRetK, 0, 0, seccompRetAllow}
+
+	prog := []sockFilter{
+		{bpfLdWAbs, 0, 0, seccompDataArch},
+		{bpfJeqK, 1, 0, seccompArch},
+		deny,
+		{bpfLdWAbs, 0, 0, seccompDataNr},
+	}
+	for _, nr := range seccompAllowed {
+		prog = append(prog, sockFilter{bpfJeqK, 0, 1, nr}, allow)
+	}
+	return append(prog,
+		sockFilter{bpfJeqK, 0, 1, seccompClone3},
+		sockFilter{bpfRetK, 0, 0, seccompRetErrno | uint32(syscall.ENOSYS)},
+		// only threads may be cloned
+		sockFilter{bpfJeqK, 0, 4, seccompClone},
+		sockFilter{bpfLdWAbs, 0, 0, seccompDataArg0},
+		sockFilter{bpfJsetK, 0, 1, cloneThread},
+		allow,
+		deny,
+		// not allowed
+		deny,
+	)
+}
+
+func installSeccomp() error {
+	filter := seccompFilter()
+	prog := sockFprog{len: uint16(len(filter)), filter: &filter[0]}
+
+	if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0, 0, 0, 0); errno != 0 {
+		return fmt.Errorf("prctl(PR_SET_NO_NEW_PRIVS): %v", errno)
+	}
+	_, _, errno := syscall.RawSyscall(seccompSyscall, seccompSetModeFilter, seccompFilterFlagTsync, uintptr(unsafe.Pointer(&prog)))
+	if errno != 0 {
+		return fmt.Errorf("seccomp: %v", errno)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package vm
+
+const (
+	seccompArch    = 0xc000003e // AUDIT_ARCH_X86_64
+	seccompSyscall = 317
+	seccompClone   = 56
+	seccompClone3  = 435
+)
+
+// x32 system calls have bit 30 set and match none of these
+var seccompAllowed = []uint32{
+	0,   // read
+	1,   // write
+	3,   // close
+	9,   // mmap
+	10,  // mprotect
+	11,  // munmap
+	12,  // brk
+	13,  // rt_sigaction
+	14,  // rt_sigprocmask
+	15,  // rt_sigreturn
+	24,  // sched_yield
+	25,  // mremap
+	28,  // madvise
+	35,  // nanosleep
+	39,  // getpid
+	60,  // exit
+	72,  // fcntl
+	131, // sigaltstack
+	158, // arch_prctl
+	186, // gettid
+	202, // futex
+	204, // sched_getaffinity
+	219, // restart_syscall
+	228, // clock_gettime
+	230, // clock_nanosleep
+	231, // exit_group
+	232, // epoll_wait
+	233, // epoll_ctl
+	234, // tgkill
+	273, // set_robust_list
+	281, // epoll_pwait
+	318, // getrandom
+	334, // rseq
+}
@@ -0,0 +1,42 @@
+package vm
+
+const (
+	seccompArch    = 0xc00000b7 // AUDIT_ARCH_AARCH64
+	seccompSyscall = 277
+	seccompClone   = 220
+	seccompClone3  = 435
+)
+
+var seccompAllowed = []uint32{
+	21,  // epoll_ctl
+	22,  // epoll_pwait
+	25,  // fcntl
+	57,  // close
+	63,  // read
+	64,  // write
+	93,  // exit
+	94,  // exit_group
+	98,  // futex
+	99,  // set_robust_list
+	101, // nanosleep
+	113, // clock_gettime
+	115, // clock_nanosleep
+	123, // sched_getaffinity
+	124, // sched_yield
+	128, // restart_syscall
+	131, // tgkill
+	132, // sigaltstack
+	134, // rt_sigaction
+	135, // rt_sigprocmask
+	139, // rt_sigreturn
+	172, // getpid
+	178, // gettid
+	214, // brk
+	215, // munmap
+	216, // mremap
+	222, // mmap
+	226, // mprotect
+	233, // madvise
+	278, // getrandom
+	293, // rseq
+}
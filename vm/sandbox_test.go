@@ -0,0 +1,144 @@
+package vm
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/log"
+	"github.com/xunleichain/tc-wasm/mock/state"
+	"github.com/xunleichain/tc-wasm/mock/types"
+)
+
+func TestMain(m *testing.M) {
+	RunSandboxHelper()
+	os.Exit(m.Run())
+}
+
+// buildSandboxLib compiles the C source of a library into dir.
+func buildSandboxLib(t *testing.T, dir, c string) string {
+	src := filepath.Join(dir, "lib.c")
+	lib := filepath.Join(dir, "lib.so")
+	if err := ioutil.WriteFile(src, []byte(c), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("gcc", "-shared", "-fPIC", "-o", lib, src).CombinedOutput(); err != nil {
+		t.Skipf("no C compiler: %v: %s", err, out)
+	}
+	return lib
+}
+
+// mallocImports are the host functions imported by testdata/malloc.wasm.
+var mallocImports = []string{"malloc", "calloc", "realloc", "prints_l", "free"}
+
+func TestSandboxCrash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sandbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lib := buildSandboxLib(t, dir, "#include <stdint.h>\n"+
+		"uint32_t thunderchain_main(void *vm, uint32_t action, uint32_t args) { *(volatile int *)0 = 1; return 0; }\n"+
+		hostTableC(mallocImports...))
+
+	code, err := ioutil.ReadFile("../testdata/malloc.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := types.BytesToAddress([]byte{1})
+	newEngine := func() (*Engine, *APP) {
+		db, _ := state.New()
+		contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+		eng := NewEngine(contract, 10000, db, log.Test())
+		app, err := eng.NewApp(addr.String(), code, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return eng, app
+	}
+
+	// the run of a node without native code
+	ieng, iapp := newEngine()
+	iret, ierr := ieng.Run(iapp, []byte("a|b"))
+	iout, _ := iapp.VM.VMemory().GetString(iret)
+
+	eng, app := newEngine()
+	eng.SetAotService(NewAotService("", true))
+
+	f, err := os.Open(lib)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.native = newSandboxNative(app, lib, f, &SandboxConfig{})
+	defer app.native.close()
+
+	ret, err := eng.Run(app, []byte("a|b"))
+	if err != ierr {
+		t.Fatalf("crash not taken over by the interpreter: %v, want %v", err, ierr)
+	}
+	if out, _ := app.VM.VMemory().GetString(ret); string(out) != string(iout) {
+		t.Fatalf("result %q, want %q", out, iout)
+	}
+	if eng.GasUsed() != ieng.GasUsed() {
+		t.Fatalf("gas used %d, want %d", eng.GasUsed(), ieng.GasUsed())
+	}
+	if app.native != nil {
+		t.Fatal("native code still used after the crash")
+	}
+	if info := eng.aots.getArtifactInfo(app, eng.aots.artifact(app)); info == nil || info.Err != aotErrCrash {
+		t.Fatalf("artifact not blacklisted: %+v", info)
+	}
+}
+
+func TestSandboxSeccomp(t *testing.T) {
+	if runtime.GOOS != "linux" || (runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64") {
+		t.Skip("no seccomp filter")
+	}
+	dir, err := ioutil.TempDir("", "sandbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// returns 7 if the calls outside the allowlist fail with EPERM
+	lib := buildSandboxLib(t, dir, "#include <stdint.h>\n#include <errno.h>\n#include <fcntl.h>\n"+
+		"#include <sys/socket.h>\n#include <unistd.h>\n"+
+		"uint32_t thunderchain_main(void *vm, uint32_t action, uint32_t args) {\n"+
+		"	if (open(\"/dev/null\", O_RDONLY) >= 0 || errno != EPERM) return 1;\n"+
+		"	if (socket(AF_INET, SOCK_STREAM, 0) >= 0 || errno != EPERM) return 2;\n"+
+		"	if (fork() >= 0 || errno != EPERM) return 3;\n"+
+		"	return 7;\n"+
+		"}\n"+
+		hostTableC(mallocImports...))
+
+	code, err := ioutil.ReadFile("../testdata/malloc.wasm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := state.New()
+	addr := types.BytesToAddress([]byte{1})
+	contract := NewContract(addr.Bytes(), addr.Bytes(), big.NewInt(0), 0)
+	eng := NewEngine(contract, 10000, db, log.Test())
+	eng.SetAotService(NewAotService("", true))
+	app, err := eng.NewApp(addr.String(), code, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(lib)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.native = newSandboxNative(app, lib, f, &SandboxConfig{})
+	defer app.native.close()
+
+	ret, err := eng.Run(app, []byte("a|b"))
+	if err != nil || ret != 7 {
+		t.Fatalf("library not run under the filter: ret %d, err %v", ret, err)
+	}
+}
@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestSigVerify(t *testing.T) {
+	eng, _ := jsonTestEngine(t)
+	app, _ := eng.RunningAppFrame()
+	vmem := app.VM.VMemory()
+	bytesArg := func(s string) uint64 {
+		data, _ := hex.DecodeString(s)
+		p, err := vmem.SetBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	flip := func(s string) string {
+		data, _ := hex.DecodeString(s)
+		data[len(data)-1] ^= 1
+		return hex.EncodeToString(data)
+	}
+
+	// RFC 8032 TEST 1
+	edPub := "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+	edSig := "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+	// ES256 signature of "sample"
+	p256Msg := hex.EncodeToString([]byte("sample"))
+	p256Pub := "bfccb6b677e3186edd57c7b78c7d1072e5e426a424e4c32b8b1f1de6b0a39023b8c0474187cb57e3c75fbc98803b0b25053098d69aa8d898fd74530192b1445a"
+	p256Sig := "53acfa6d0c7be1abe948e8c5a67b8587c59e7196937fcb6220371f26cb9520cfe42fe438e4943980d79cde0143ec1c36c49956a391393a0ebfa5e77b3c1a742e"
+
+	tests := []struct {
+		fn               string
+		msg, pubkey, sig string
+		want             uint64
+	}{
+		{"ed25519", "", edPub, edSig, 1},
+		{"ed25519", "72", edPub, edSig, 0},
+		{"ed25519", "", flip(edPub), edSig, 0},
+		{"ed25519", "", edPub, flip(edSig), 0},
+		{"p256", p256Msg, p256Pub, p256Sig, 1},
+		{"p256", "", p256Pub, p256Sig, 0},
+		{"p256", p256Msg, flip(p256Pub), p256Sig, 0},
+		{"p256", p256Msg, p256Pub, flip(p256Sig), 0},
+	}
+	for i, test := range tests {
+		args := []uint64{bytesArg(test.msg), uint64(len(test.msg) / 2), bytesArg(test.pubkey), bytesArg(test.sig)}
+		var (
+			ret uint64
+			err error
+		)
+		if test.fn == "ed25519" {
+			ret, err = tcEd25519Verify(eng, -1, args)
+		} else {
+			ret, err = tcP256Verify(eng, -1, args)
+		}
+		if err != nil || ret != test.want {
+			t.Errorf("%d: %s verify got %d, %v want %d", i, test.fn, ret, err, test.want)
+		}
+	}
+
+	if _, err := tcEd25519Verify(eng, -1, []uint64{0, 1<<32 - 1, 0, 0}); err != ErrInvalidApiArgs {
+		t.Errorf("negative message length: %v", err)
+	}
+	if gas, _ := gasP256Verify(eng, -1, []uint64{0, 65}); gas != P256VerifyGas+3*P256PerWordGas {
+		t.Errorf("p256 gas %d", gas)
+	}
+}
@@ -0,0 +1,519 @@
+package vm
+
+import (
+	"bytes"
+	"debug/dwarf"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-interpreter/wagon/disasm"
+	"github.com/go-interpreter/wagon/wasm"
+	ops "github.com/go-interpreter/wagon/wasm/operators"
+)
+
+var (
+	errBadLEB128  = errors.New("source: invalid leb128")
+	errBadCode    = errors.New("source: invalid code section")
+	errCodeLayout = errors.New("source: compiled code layout mismatch")
+)
+
+// SourceLine is a position in the C/C++ source of a contract.
+type SourceLine struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (l SourceLine) String() string {
+	if l.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// debugInfo maps interpreter program counters back to wasm code offsets and,
+// when the module was built with -g, to source lines. Code offsets are
+// relative to the code section payload, the address space DWARF uses for
+// wasm. It is shared by all clones of an APP.
+type debugInfo struct {
+	bodies  []uint32 // offset of the first instruction of each function body
+	imports int
+	lines   *lineTable
+
+	lock  sync.Mutex
+	funcs map[int64]*funcMap
+}
+
+// funcMap holds, for one function, the interpreter pc where every emitted
+// instruction starts along with the wasm code offset and opcode it came from.
+// blocks numbers the basic block of each instruction, a block starting after
+// every control instruction and at every block end.
+type funcMap struct {
+	pcs    []int64
+	offs   []uint32
+	ops    []byte
+	blocks []int
+	leader []bool
+}
+
+func (fm *funcMap) numBlocks() int {
+	if len(fm.blocks) == 0 {
+		return 0
+	}
+	return fm.blocks[len(fm.blocks)-1] + 1
+}
+
+func (fm *funcMap) lookup(pc int64) int {
+	i := sort.Search(len(fm.pcs), func(i int) bool { return fm.pcs[i] > pc }) - 1
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+func newDebugInfo(m *wasm.Module, code []byte) *debugInfo {
+	info := &debugInfo{
+		funcs: make(map[int64]*funcMap),
+	}
+	if m.Code == nil {
+		return info
+	}
+
+	// imported functions come first in the function index space
+	imports := 0
+	for imports < len(m.FunctionIndexSpace) && m.FunctionIndexSpace[imports].IsHost() {
+		imports++
+	}
+	bodies, err := codeBodyOffsets(code)
+	if err != nil || len(bodies) != len(m.FunctionIndexSpace)-imports {
+		return info
+	}
+	info.bodies = bodies
+	info.imports = imports
+
+	if m.Custom(".debug_line") != nil {
+		info.lines, _ = newLineTable(m)
+	}
+	return info
+}
+
+// funcMap returns the pc map of fnIndex, building it on first use.
+func (info *debugInfo) funcMap(app *APP, fnIndex int64) *funcMap {
+	if info == nil || info.bodies == nil {
+		return nil
+	}
+	body := int(fnIndex) - info.imports
+	if body < 0 || body >= len(info.bodies) {
+		return nil
+	}
+
+	info.lock.Lock()
+	defer info.lock.Unlock()
+	if fm, ok := info.funcs[fnIndex]; ok {
+		return fm
+	}
+
+	fm, err := buildFuncMap(app, fnIndex, info.bodies[body])
+	if err != nil {
+		app.logger.Debug("[APP] build pc map fail", "app", app.String(), "func", fnIndex, "err", err)
+		fm = nil
+	}
+	info.funcs[fnIndex] = fm
+	return fm
+}
+
+// CodeOffset returns the wasm code offset of the instruction at the
+// interpreter pc of function fnIndex.
+func (app *APP) CodeOffset(fnIndex, pc int64) (uint32, bool) {
+	fm := app.dbg.funcMap(app, fnIndex)
+	if fm == nil || len(fm.pcs) == 0 {
+		return 0, false
+	}
+	return fm.offs[fm.lookup(pc)], true
+}
+
+// opName returns the name of the wasm instruction at pc of fnIndex.
+func (app *APP) opName(fnIndex, pc int64) string {
+	fm := app.dbg.funcMap(app, fnIndex)
+	if fm == nil || len(fm.pcs) == 0 {
+		return ""
+	}
+	op, err := ops.New(fm.ops[fm.lookup(pc)])
+	if err != nil {
+		return ""
+	}
+	return op.Name
+}
+
+// SourceLine returns the source position of the instruction at the
+// interpreter pc of function fnIndex. It needs the DWARF sections emitted
+// by clang -g to be kept in the contract.
+func (app *APP) SourceLine(fnIndex, pc int64) (SourceLine, bool) {
+	if app.dbg == nil || app.dbg.lines == nil {
+		return SourceLine{}, false
+	}
+	off, ok := app.CodeOffset(fnIndex, pc)
+	if !ok {
+		return SourceLine{}, false
+	}
+	return app.dbg.lines.lookup(uint64(off))
+}
+
+// HasSourceMap reports whether the contract carries DWARF line information.
+func (app *APP) HasSourceMap() bool {
+	return app.dbg != nil && app.dbg.lines != nil
+}
+
+func (app *APP) funcName(fnIndex int64) string {
+	if fn := app.Module.GetFunction(int(fnIndex)); fn != nil && fn.Name != "" {
+		return fn.Name
+	}
+	return fmt.Sprintf("func[%d]", fnIndex)
+}
+
+// locate describes the instruction at pc of fnIndex as "func+0xoff" and,
+// when known, its source line.
+func (app *APP) locate(fnIndex, pc int64) (string, *SourceLine) {
+	name := app.funcName(fnIndex)
+	off, ok := app.CodeOffset(fnIndex, pc)
+	if !ok {
+		return fmt.Sprintf("%s@pc%d", name, pc), nil
+	}
+	where := fmt.Sprintf("%s+0x%x", name, off)
+	if line, ok := app.SourceLine(fnIndex, pc); ok {
+		return where, &line
+	}
+	return where, nil
+}
+
+// buildFuncMap replays the layout decisions of wagon's internal compiler
+// (exec/internal/compile) over the function body to learn where each wasm
+// instruction lands in the interpreter bytecode. The result is checked
+// against the length of the real compiled code.
+func buildFuncMap(app *APP, fnIndex int64, base uint32) (*funcMap, error) {
+	fn := app.Module.GetFunction(int(fnIndex))
+	if fn == nil || fn.Body == nil {
+		return nil, errBadCode
+	}
+	d, err := disasm.NewDisassembly(*fn, app.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := fn.Body.Code
+	fm := &funcMap{}
+	block, leader := -1, true
+	emit := func(pc int64, off int, op byte) {
+		if leader {
+			block++
+		}
+		fm.pcs = append(fm.pcs, pc)
+		fm.offs = append(fm.offs, base+uint32(off))
+		fm.ops = append(fm.ops, op)
+		fm.blocks = append(fm.blocks, block)
+		fm.leader = append(fm.leader, leader)
+		leader = false
+	}
+
+	var pc int64
+	off := 0
+	for _, instr := range d.Code {
+		n, err := wasmInstrLen(raw[off:])
+		if err != nil {
+			return nil, err
+		}
+		cur := off
+		off += n
+		if instr.Unreachable {
+			continue
+		}
+
+		op := instr.Op.Code
+		size := int64(0)
+		switch op {
+		case ops.I32Load, ops.I64Load, ops.F32Load, ops.F64Load, ops.I32Load8s, ops.I32Load8u, ops.I32Load16s, ops.I32Load16u, ops.I64Load8s, ops.I64Load8u, ops.I64Load16s, ops.I64Load16u, ops.I64Load32s, ops.I64Load32u, ops.I32Store, ops.I64Store, ops.F32Store, ops.F64Store, ops.I32Store8, ops.I32Store16, ops.I64Store8, ops.I64Store16, ops.I64Store32:
+			size = 1 + 4
+		case ops.If:
+			size = 9
+		case ops.Loop, ops.Block:
+			leader = true
+			continue
+		case ops.Else:
+			ifInstr := d.Code[instr.Block.ElseIfIndex]
+			if ifInstr.NewStack != nil && ifInstr.NewStack.StackTopDiff != 0 {
+				size += 9
+			}
+			size += 9
+		case ops.End:
+			if instr.NewStack != nil && instr.NewStack.StackTopDiff != 0 {
+				size = 9
+			}
+		case ops.Br:
+			if instr.NewStack != nil && instr.NewStack.StackTopDiff != 0 {
+				size += 9
+			}
+			size += 9
+		case ops.BrIf:
+			size = 18
+		case ops.BrTable:
+			size = 9
+		default:
+			size = 1
+			for _, imm := range instr.Immediates {
+				n := binary.Size(imm)
+				if n < 0 {
+					return nil, fmt.Errorf("source: unsupported immediate %T", imm)
+				}
+				size += int64(n)
+			}
+		}
+		if op == ops.End {
+			// a branch target
+			leader = true
+		}
+		if size > 0 {
+			emit(pc, cur, op)
+			pc += size
+		}
+		switch op {
+		case ops.If, ops.Else, ops.End, ops.Loop, ops.Block, ops.Br, ops.BrIf, ops.BrTable, ops.Return, ops.Unreachable:
+			leader = true
+		}
+	}
+	// wagon strips the final end of a body, the compiler puts a nop there
+	emit(pc, len(raw), ops.Nop)
+	pc++
+
+	if app.VM != nil {
+		if code := app.VM.CompiledCode(fnIndex); code != nil && int64(len(code)) != pc {
+			return nil, errCodeLayout
+		}
+	}
+	return fm, nil
+}
+
+func skipLEB128(b []byte) (int, error) {
+	for i := 0; i < len(b) && i < 10; i++ {
+		if b[i]&0x80 == 0 {
+			return i + 1, nil
+		}
+	}
+	return 0, errBadLEB128
+}
+
+func readULEB128(b []byte) (uint32, int, error) {
+	var v uint32
+	for i := 0; i < len(b) && i < 5; i++ {
+		v |= uint32(b[i]&0x7f) << (7 * uint(i))
+		if b[i]&0x80 == 0 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, errBadLEB128
+}
+
+// wasmInstrLen returns the encoded length of the instruction at the head of b.
+func wasmInstrLen(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, errBadCode
+	}
+	n := 1
+	leb := func(count int) error {
+		for i := 0; i < count; i++ {
+			l, err := skipLEB128(b[n:])
+			if err != nil {
+				return err
+			}
+			n += l
+		}
+		return nil
+	}
+
+	var err error
+	switch op := b[0]; {
+	case op == ops.Block || op == ops.Loop || op == ops.If:
+		n++
+	case op == ops.Br || op == ops.BrIf || op == ops.Call:
+		err = leb(1)
+	case op == ops.BrTable:
+		var count uint32
+		var l int
+		count, l, err = readULEB128(b[n:])
+		if err == nil {
+			n += l
+			err = leb(int(count) + 1)
+		}
+	case op == ops.CallIndirect:
+		err = leb(2)
+	case op >= ops.GetLocal && op <= ops.SetGlobal:
+		err = leb(1)
+	case op >= ops.I32Load && op <= ops.I64Store32:
+		err = leb(2)
+	case op == ops.CurrentMemory || op == ops.GrowMemory:
+		err = leb(1)
+	case op == ops.I32Const || op == ops.I64Const:
+		err = leb(1)
+	case op == ops.F32Const:
+		n += 4
+	case op == ops.F64Const:
+		n += 8
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n > len(b) {
+		return 0, errBadCode
+	}
+	return n, nil
+}
+
+// codeBodyOffsets scans a raw wasm module and returns the offset of the first
+// instruction of every function body, relative to the code section payload.
+func codeBodyOffsets(code []byte) ([]uint32, error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], WasmBytes) {
+		return nil, errBadCode
+	}
+
+	pos := 8
+	for pos < len(code) {
+		id := code[pos]
+		size, l, err := readULEB128(code[pos+1:])
+		if err != nil {
+			return nil, err
+		}
+		start := pos + 1 + l
+		end := start + int(size)
+		if end > len(code) {
+			return nil, errBadCode
+		}
+		if id == byte(wasm.SectionIDCode) {
+			return parseCodeBodies(code[start:end])
+		}
+		pos = end
+	}
+	return nil, nil
+}
+
+func parseCodeBodies(payload []byte) ([]uint32, error) {
+	count, pos, err := readULEB128(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]uint32, 0, count)
+	for i := uint32(0); i < count; i++ {
+		size, l, err := readULEB128(payload[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += l
+		end := pos + int(size)
+		if end > len(payload) {
+			return nil, errBadCode
+		}
+
+		p := pos
+		locals, l, err := readULEB128(payload[p:end])
+		if err != nil {
+			return nil, err
+		}
+		p += l
+		for j := uint32(0); j < locals; j++ {
+			l, err := skipLEB128(payload[p:end])
+			if err != nil {
+				return nil, err
+			}
+			p += l + 1 // count, value type
+		}
+		bodies = append(bodies, uint32(p))
+		pos = end
+	}
+	return bodies, nil
+}
+
+type lineRow struct {
+	addr uint64
+	end  bool
+	line SourceLine
+}
+
+type lineTable struct {
+	rows []lineRow
+}
+
+// newLineTable decodes the DWARF line programs stored in the custom
+// sections of m.
+func newLineTable(m *wasm.Module) (*lineTable, error) {
+	section := func(name string) []byte {
+		if s := m.Custom(name); s != nil {
+			return s.Data
+		}
+		return nil
+	}
+
+	d, err := dwarf.New(section(".debug_abbrev"), nil, nil, section(".debug_info"),
+		section(".debug_line"), nil, section(".debug_ranges"), section(".debug_str"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{".debug_line_str", ".debug_str_offsets", ".debug_addr", ".debug_rnglists"} {
+		if data := section(name); data != nil {
+			if err := d.AddSection(name, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	table := &lineTable{}
+	r := d.Reader()
+	for {
+		e, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			break
+		}
+		if e.Tag != dwarf.TagCompileUnit {
+			r.SkipChildren()
+			continue
+		}
+
+		lr, err := d.LineReader(e)
+		if err != nil {
+			return nil, err
+		}
+		r.SkipChildren()
+		if lr == nil {
+			continue
+		}
+
+		var le dwarf.LineEntry
+		for lr.Next(&le) == nil {
+			row := lineRow{addr: le.Address, end: le.EndSequence}
+			if !le.EndSequence && le.File != nil {
+				row.line = SourceLine{File: le.File.Name, Line: le.Line, Column: le.Column}
+			}
+			table.rows = append(table.rows, row)
+		}
+	}
+
+	sort.SliceStable(table.rows, func(i, j int) bool {
+		if table.rows[i].addr != table.rows[j].addr {
+			return table.rows[i].addr < table.rows[j].addr
+		}
+		return table.rows[i].end && !table.rows[j].end
+	})
+	return table, nil
+}
+
+func (t *lineTable) lookup(addr uint64) (SourceLine, bool) {
+	i := sort.Search(len(t.rows), func(i int) bool { return t.rows[i].addr > addr }) - 1
+	if i < 0 || t.rows[i].end || t.rows[i].line.Line == 0 {
+		return SourceLine{}, false
+	}
+	return t.rows[i].line, true
+}
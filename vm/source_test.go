@@ -0,0 +1,107 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/go-interpreter/wagon/wasm"
+)
+
+// testLineModule builds a DWARF v4 compile unit for "a.c" whose line program
+// maps code offsets 0x10, 0x18 and 0x30 to lines 3, 5 and 9.
+func testLineModule() *wasm.Module {
+	abbrev := []byte{
+		1, 0x11, 0, // abbrev 1: compile_unit, no children
+		0x03, 0x08, // name, string
+		0x10, 0x17, // stmt_list, sec_offset
+		0, 0, 0,
+	}
+
+	info := new(bytes.Buffer)
+	info.Write([]byte{4, 0, 0, 0, 0, 0, 4}) // version, abbrev offset, address size
+	info.WriteByte(1)
+	info.WriteString("a.c\x00")
+	info.Write([]byte{0, 0, 0, 0})
+
+	header := new(bytes.Buffer)
+	header.Write([]byte{1, 1, 1, 0xfb, 14, 13})              // min inst, max ops, is_stmt, line base, line range, opcode base
+	header.Write([]byte{0, 1, 1, 1, 1, 0, 0, 0, 1, 0, 0, 1}) // standard opcode lengths
+	header.WriteByte(0)                                      // no include directories
+	header.WriteString("a.c\x00")
+	header.Write([]byte{0, 0, 0, 0})
+
+	program := []byte{
+		0, 5, 2, 0x10, 0, 0, 0, // set_address 0x10
+		3, 2, 1, // advance_line 2, copy
+		2, 8, 3, 2, 1, // advance_pc 8, advance_line 2, copy
+		2, 0x18, 3, 4, 1, // advance_pc 0x18, advance_line 4, copy
+		2, 4, 0, 1, 1, // advance_pc 4, end_sequence
+	}
+
+	line := new(bytes.Buffer)
+	line.Write([]byte{4, 0})
+	binary.Write(line, binary.LittleEndian, uint32(header.Len()))
+	line.Write(header.Bytes())
+	line.Write(program)
+
+	withLength := func(b []byte) []byte {
+		out := make([]byte, 4, 4+len(b))
+		binary.LittleEndian.PutUint32(out, uint32(len(b)))
+		return append(out, b...)
+	}
+
+	return &wasm.Module{
+		Customs: []*wasm.SectionCustom{
+			{Name: ".debug_abbrev", Data: abbrev},
+			{Name: ".debug_info", Data: withLength(info.Bytes())},
+			{Name: ".debug_line", Data: withLength(line.Bytes())},
+		},
+	}
+}
+
+func TestLineTable(t *testing.T) {
+	table, err := newLineTable(testLineModule())
+	if err != nil {
+		t.Fatalf("newLineTable fail: %s", err)
+	}
+
+	tests := []struct {
+		addr uint64
+		line int
+		ok   bool
+	}{
+		{0x0f, 0, false},
+		{0x10, 3, true},
+		{0x17, 3, true},
+		{0x18, 5, true},
+		{0x2f, 5, true},
+		{0x30, 9, true},
+		{0x34, 0, false},
+	}
+	for _, test := range tests {
+		l, ok := table.lookup(test.addr)
+		if ok != test.ok || l.Line != test.line {
+			t.Fatalf("lookup(0x%x): wanted(%d, %v), got(%d, %v)", test.addr, test.line, test.ok, l.Line, ok)
+		}
+		if ok && l.File != "a.c" {
+			t.Fatalf("lookup(0x%x): wanted file a.c, got %s", test.addr, l.File)
+		}
+	}
+}
+
+func TestCodeBodyOffsets(t *testing.T) {
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 1, 0, 0, 0,
+		10, 10, // code section
+		2,          // two bodies
+		2, 0, 0x0b, // no locals
+		5, 1, 2, 0x7f, 0x20, 0x0b, // one local entry
+	}
+	bodies, err := codeBodyOffsets(code)
+	if err != nil {
+		t.Fatalf("codeBodyOffsets fail: %s", err)
+	}
+	if len(bodies) != 2 || bodies[0] != 3 || bodies[1] != 8 {
+		t.Fatalf("unexpected body offsets: %v", bodies)
+	}
+}
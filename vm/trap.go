@@ -0,0 +1,64 @@
+package vm
+
+import "fmt"
+
+// Trap records where the innermost contract frame stopped when Engine.Run
+// returned an error.
+type Trap struct {
+	App    string
+	Func   int64
+	PC     int64
+	Where  string
+	Source *SourceLine
+	Err    error
+}
+
+func (t *Trap) String() string {
+	if t.Source != nil {
+		return fmt.Sprintf("%s at %s (%s): %v", t.App, t.Where, t.Source, t.Err)
+	}
+	return fmt.Sprintf("%s at %s: %v", t.App, t.Where, t.Err)
+}
+
+// Trap returns the location of the last failed Run, or nil.
+func (eng *Engine) Trap() *Trap {
+	return eng.trap
+}
+
+// recordTrap keeps the first (innermost) failing frame of a Run.
+func (eng *Engine) recordTrap(app *APP, err error) {
+	if eng.trap != nil {
+		return
+	}
+
+	trap := &Trap{
+		App:   app.String(),
+		Func:  -1,
+		PC:    -1,
+		Where: "-",
+		Err:   err,
+	}
+	if app.runsNative() {
+		trap.Where = "native"
+	}
+	if frame := app.CurrentFrame(); frame != nil {
+		trap.Func = frame.Func
+		trap.PC = frame.PC
+		trap.Where, trap.Source = app.locate(frame.Func, instrPC(frame.PC))
+	}
+	eng.trap = trap
+	eng.logger.Debug("[Engine] trap", "app", trap.App, "at", trap.Where, "src", trap.Source, "err", err)
+
+	if eng.dumpOnFailure {
+		eng.takeDump(app, trap)
+	}
+}
+
+// instrPC turns the pc saved by the interpreter, which already points past
+// the opcode being executed, back into that instruction.
+func instrPC(pc int64) int64 {
+	if pc > 0 {
+		return pc - 1
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+// A verified Run executes the contract on the interpreter, which is
+// authoritative and changes the state, while recording its host calls. The
+// native code then replays that recording on a copy of the Engine, so it
+// reads the same state and its writes, logs and sub-calls are compared
+// instead of applied. Any difference in return bytes, gas, memory or host
+// calls blacklists the native library of the contract.
+//
+// Host functions registered with plain RegisterFunc would run a second time
+// and apply their effects twice, so contracts importing one are not
+// verified. The native side runs on a stateOverlay of Engine.State, so its
+// writes never reach the state and a mismatch is recorded in the state the
+// interpreter left behind.
+
+// stateSnapshotter is implemented by StateDBs with a journal.
+type stateSnapshotter interface {
+	Snapshot() int
+	RevertToSnapshot(revid int)
+}
+
+// stateOverlay is a StateDB that reads through to a base StateDB and keeps
+// the contract infos written to it.
+type stateOverlay struct {
+	StateDB
+	info map[string][]byte
+}
+
+func newStateOverlay(base StateDB) *stateOverlay {
+	return &stateOverlay{StateDB: base, info: make(map[string][]byte)}
+}
+
+func (s *stateOverlay) GetContractInfo(key []byte) []byte {
+	if v, ok := s.info[string(key)]; ok {
+		return v
+	}
+	return s.StateDB.GetContractInfo(key)
+}
+
+func (s *stateOverlay) SetContractInfo(key, value []byte) {
+	s.info[string(key)] = append([]byte(nil), value...)
+}
+
+// VerifyResult is the outcome of one side of a verified Run.
+type VerifyResult struct {
+	Ret     []byte
+	GasUsed uint64
+	Err     string
+}
+
+// VerifyReport describes a mismatch between the interpreter and native code.
+type VerifyReport struct {
+	App    string
+	Input  string
+	Reason string
+	Interp VerifyResult
+	Native VerifyResult
+}
+
+func (r *VerifyReport) String() string {
+	return fmt.Sprintf("%s input %q: %s (interp ret=%q gas=%d err=%q, native ret=%q gas=%d err=%q)",
+		r.App, r.Input, r.Reason,
+		r.Interp.Ret, r.Interp.GasUsed, r.Interp.Err,
+		r.Native.Ret, r.Native.GasUsed, r.Native.Err)
+}
+
+// shouldVerify samples the top level runs of apps with native code at the
+// verify rate of the AotService.
+func (eng *Engine) shouldVerify(app *APP) bool {
+	s := eng.aots
+	if s == nil || s.verifyRate <= 0 || app.native == nil || app.IsPreRun {
+		return false
+	}
+	if eng.runningFrame != nil || eng.recorder != nil || eng.replayer != nil || eng.interpreterOnly() {
+		return false
+	}
+	if !app.replayable() {
+		return false
+	}
+	return s.verifyRate >= 1 || rand.Float64() < s.verifyRate
+}
+
+func (eng *Engine) runVerified(app *APP, action, args string) (uint64, error) {
+	gas, gasUsed := eng.gas, eng.gasUsed
+
+	buf := new(bytes.Buffer)
+	eng.recorder = NewRecorder(buf)
+	eng.verifying = true
+	ret, err := eng.run(app, action, args)
+	eng.verifying = false
+	eng.recorder = nil
+
+	interp := verifyResult(app, ret, eng.gasUsed-gasUsed, err)
+	interpMem := app.VM.Memory()
+
+	cached := eng.AppByName(app.Name)
+	replayer, rerr := NewReplayer(buf)
+	if cached == nil || rerr != nil {
+		return ret, err
+	}
+	// the results are compared below, error messages of both sides may differ
+	replayer.checkReturn = false
+
+	veng := &Engine{
+		logger:     eng.logger,
+		State:      newStateOverlay(eng.State),
+		AppCache:   eng.AppCache,
+		aots:       eng.aots,
+		Env:        eng.Env,
+		AppFrames:  make([]*APP, maxFrames),
+		FrameIndex: -1,
+		gas:        gas,
+		gasUsed:    gasUsed,
+		Contract:   eng.Contract,
+		Ctx:        eng.Ctx,
+		jsonMemory: eng.jsonMemory,
+		replayer:   replayer,
+	}
+	napp := cached.Clone(veng)
+	napp.EntryFunc = app.EntryFunc
+	if napp.native == nil {
+		return ret, err
+	}
+	nret, nerr := veng.run(napp, action, args)
+	eng.aots.lock.Lock()
+	eng.aots.stats.Verified++
+	eng.aots.lock.Unlock()
+	native := verifyResult(napp, nret, veng.gasUsed-gasUsed, nerr)
+
+	reason := ""
+	switch {
+	case replayer.Divergence() != nil:
+		reason = "host calls differ: " + replayer.Divergence().String()
+	case replayer.Remaining() != 0:
+		reason = fmt.Sprintf("native code made %d host calls less", replayer.Remaining())
+	case (interp.Err == "") != (native.Err == ""):
+		reason = "only one side failed"
+	case interp.GasUsed != native.GasUsed:
+		reason = "gas used differs"
+	case !bytes.Equal(interp.Ret, native.Ret):
+		reason = "return values differ"
+	case interp.Err == "" && !bytes.Equal(interpMem, napp.VM.Memory()):
+		reason = "memory differs"
+	}
+	if reason != "" {
+		report := &VerifyReport{
+			App:    app.String(),
+			Input:  action + "|" + args,
+			Reason: reason,
+			Interp: interp,
+			Native: native,
+		}
+		eng.aots.verifyFailed(app, report)
+	}
+	return ret, err
+}
+
+func verifyResult(app *APP, ret, gasUsed uint64, err error) VerifyResult {
+	r := VerifyResult{GasUsed: gasUsed}
+	if err != nil {
+		r.Err = err.Error()
+	} else if ret != 0 {
+		r.Ret, _ = app.VM.VMemory().GetString(ret)
+	}
+	return r
+}
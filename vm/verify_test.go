@@ -0,0 +1,89 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/xunleichain/tc-wasm/mock/state"
+)
+
+// plainFunc is a host function registered with plain RegisterFunc.
+type plainFunc struct {
+	EnvFunc
+}
+
+func TestVerifyReplayable(t *testing.T) {
+	eng, _ := jsonTestEngine(t)
+	app := eng.runningFrame
+	if !app.replayable() {
+		t.Fatal("malloc.wasm only imports builtin host functions")
+	}
+
+	for i := range app.Module.FunctionIndexSpace {
+		fn := &app.Module.FunctionIndexSpace[i]
+		if !fn.IsHost() {
+			continue
+		}
+		host := fn.Host
+		fn.Host = &plainFunc{host.(EnvFunc)}
+		ok := app.replayable()
+		fn.Host = host
+		if ok {
+			t.Fatalf("%s registered with RegisterFunc is replayable", fn.Name)
+		}
+		return
+	}
+	t.Fatal("malloc.wasm imports no host function")
+}
+
+func TestVerifyHostClasses(t *testing.T) {
+	env := NewEnvTable()
+	for _, name := range env.Exports.Names {
+		switch env.Module.FunctionIndexSpace[env.Exports.Entries[name].Index].Host.(type) {
+		case *stateFunc, *pureFunc, *frameFunc:
+		default:
+			t.Errorf("%s is registered with plain RegisterFunc", name)
+		}
+	}
+
+	classes := []struct {
+		name string
+		want string
+	}{
+		{"TC_CallContract", "*vm.stateFunc"},
+		{"malloc", "*vm.frameFunc"},
+		{"free", "*vm.frameFunc"},
+		{"TC_JsonParse", "*vm.frameFunc"},
+		{"TC_JsonFree", "*vm.frameFunc"},
+		{"TC_GetMsgSender", "*vm.frameFunc"},
+		{"TC_GasLeft", "*vm.frameFunc"},
+		{"TC_Sha256", "*vm.pureFunc"},
+		{"TC_BigIntAdd", "*vm.pureFunc"},
+		{"memcpy", "*vm.pureFunc"},
+	}
+	for _, c := range classes {
+		host := env.Module.FunctionIndexSpace[env.Exports.Entries[c.name].Index].Host
+		if got := fmt.Sprintf("%T", host); got != c.want {
+			t.Errorf("%s is a %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestVerifyStateOverlay(t *testing.T) {
+	db, _ := state.New()
+	db.SetContractInfo([]byte("a"), []byte("1"))
+
+	overlay := newStateOverlay(db)
+	overlay.SetContractInfo([]byte("a"), []byte("2"))
+	overlay.SetContractInfo([]byte("b"), []byte("3"))
+	if v := overlay.GetContractInfo([]byte("a")); !bytes.Equal(v, []byte("2")) {
+		t.Fatalf("overlay reads %q, want its own write", v)
+	}
+	if v := db.GetContractInfo([]byte("a")); !bytes.Equal(v, []byte("1")) {
+		t.Fatalf("base reads %q after a write to the overlay", v)
+	}
+	if v := db.GetContractInfo([]byte("b")); len(v) != 0 {
+		t.Fatalf("base reads %q after a write to the overlay", v)
+	}
+}